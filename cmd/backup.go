@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var backupInstance string
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Back up the data of an embedded MindsDB instance",
+	Long: `Archive the MindsDB storage directory of an embedded instance (models,
+databases, ML engines, jobs, knowledge bases and uploaded files) into a
+gzip-compressed tarball.
+
+Without a file name the backup is written to
+mindsdb-<instance>-<timestamp>.tar.gz in the current directory. The instance
+may be running, but stopping it first gives a consistent snapshot. When the
+container was removed (e.g. with 'stop --remove') but its data volume is
+left, the volume is read through a temporary container.
+
+Restore a backup with 'mindsdb-cli restore <file>'.
+
+Examples:
+  mindsdb-cli backup
+  mindsdb-cli backup ./before-upgrade.tar.gz
+  mindsdb-cli backup --instance scratch`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mindsdb.ValidateInstanceName(backupInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+
+		client := &mindsdb.MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: backupInstance}
+		container, err := client.Container()
+		if err != nil {
+			fmt.Printf("❌ Failed to get container status: %v\n", err)
+			return
+		}
+		if container == nil {
+			hasData, err := client.DataVolumeExists(context.Background())
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				return
+			}
+			if !hasData {
+				fmt.Printf("ℹ️  No MindsDB container or data volume found%s\n", instanceSuffix(backupInstance))
+				return
+			}
+		} else if container.Running {
+			fmt.Printf("⚠️  The instance is running; for a consistent backup run 'mindsdb-cli stop%s' first\n", mindsdb.InstanceArg(backupInstance))
+		}
+
+		file := fmt.Sprintf("mindsdb-%s-%s.tar.gz", backupInstanceName(), time.Now().Format("20060102-150405"))
+		if len(args) == 1 {
+			file = args[0]
+		}
+
+		// Backups may contain integration credentials, so keep them private
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		fmt.Printf("💾 Backing up %s to %s...\n", mindsdb.InstanceContainerName(backupInstance), file)
+		err = client.BackupEmbeddedMindsDB(context.Background(), f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(file)
+			fmt.Printf("❌ Backup failed: %v\n", err)
+			return
+		}
+
+		if info, err := os.Stat(file); err == nil {
+			fmt.Printf("✅ Backup written to %s (%.1f MB)\n", file, float64(info.Size())/(1<<20))
+		}
+		fmt.Printf("💡 Use 'mindsdb-cli restore %s%s' to restore it\n", file, mindsdb.InstanceArg(backupInstance))
+	},
+}
+
+// backupInstanceName returns the instance name used in default backup
+// file names
+func backupInstanceName() string {
+	if backupInstance == "" {
+		return mindsdb.DefaultInstance
+	}
+	return backupInstance
+}
+
+func init() {
+	registerInstanceFlag(backupCmd, &backupInstance)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"mindsdb-go-cli/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage connection profiles",
+	Long: `Manage the named connection profiles stored in the CLI config file
+(~/.config/mindsdb-cli/config.yaml by default, override with MINDSDB_CLI_CONFIG).
+
+Profiles are created with 'mindsdb-cli connect --save <name>'. The active
+profile is used by query, list-models and create-model unless --profile
+or explicit connection flags are given.
+
+Examples:
+  mindsdb-cli config list-profiles
+  mindsdb-cli config use-profile cloud
+  mindsdb-cli config delete-profile old-staging`,
+}
+
+var configUseProfileCmd = &cobra.Command{
+	Use:   "use-profile <name>",
+	Short: "Set the active connection profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		if _, err := cfg.Profile(args[0]); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			fmt.Println("💡 Use 'mindsdb-cli connect --save <name>' to create a profile")
+			return
+		}
+
+		cfg.CurrentProfile = args[0]
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("❌ Failed to save config: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Active profile is now '%s'\n", args[0])
+	},
+}
+
+var configListProfilesCmd = &cobra.Command{
+	Use:   "list-profiles",
+	Short: "List saved connection profiles",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		if len(cfg.Profiles) == 0 {
+			fmt.Println("ℹ️  No profiles saved yet")
+			fmt.Println("💡 Use 'mindsdb-cli connect --save <name>' to create one")
+			return
+		}
+
+		fmt.Printf("📋 Profiles (%s):\n", cfg.File())
+		for _, name := range cfg.ProfileNames() {
+			profile := cfg.Profiles[name]
+			marker := "  "
+			if name == cfg.CurrentProfile {
+				marker = "* "
+			}
+
+			target := "embedded"
+			if !profile.Embedded {
+				target = fmt.Sprintf("%s (%s)", profile.Host, profile.Protocol)
+			}
+			if profile.User != "" {
+				target += ", user " + profile.User
+			}
+			fmt.Printf("  %s%-16s %s\n", marker, name, target)
+		}
+	},
+}
+
+var configDeleteProfileCmd = &cobra.Command{
+	Use:   "delete-profile <name>",
+	Short: "Delete a saved connection profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		if err := cfg.DeleteProfile(args[0]); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("❌ Failed to save config: %v\n", err)
+			return
+		}
+		fmt.Printf("🗑️  Deleted profile '%s'\n", args[0])
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configUseProfileCmd)
+	configCmd.AddCommand(configListProfilesCmd)
+	configCmd.AddCommand(configDeleteProfileCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// confirm asks a yes/no question on the terminal. Without a terminal it
+// answers no, so scripts have to pass --force for destructive actions.
+func confirm(question string) bool {
+	return confirmFlag(question, "--force")
+}
+
+// confirmFlag is like confirm but names the flag scripts have to pass
+func confirmFlag(question, flag string) bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("   Not a terminal; pass %s to confirm.\n", flag)
+		return false
+	}
+
+	fmt.Printf("❓ %s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
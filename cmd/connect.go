@@ -1,14 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"mindsdb-go-cli/internal/mindsdb"
+	"mindsdb-go-cli/internal/config"
 
 	"github.com/spf13/cobra"
 )
 
-var host, user, pass string
-var embedded bool
+var connectConn connectionFlags
+var saveProfile string
 
 var connectCmd = &cobra.Command{
 	Use:   "connect",
@@ -16,56 +17,45 @@ var connectCmd = &cobra.Command{
 	Long: `Connect to either an external MindsDB instance or an embedded one.
     
 Examples:
-  # Connect to external MindsDB
-  mindsdb-cli connect --host localhost:47335 --user admin --pass admin
+  # Connect to external MindsDB (prompts for the password)
+  mindsdb-cli connect --host localhost:47335 --user admin
   
   # Connect to embedded MindsDB (requires Docker)
-  mindsdb-cli connect --embedded --user admin --pass admin
+  mindsdb-cli connect --embedded
   
-  # Connect to MindsDB Cloud
-  mindsdb-cli connect --host cloud.mindsdb.com --user your-email --pass your-password`,
-	Run: func(cmd *cobra.Command, args []string) {
-		var client *mindsdb.MindsDBClient
-		var err error
+  # Connect to MindsDB Cloud, reading the password from the environment
+  MINDSDB_PASSWORD=your-password mindsdb-cli connect --host cloud.mindsdb.com --user your-email
 
-		if embedded {
-			fmt.Println("🔗 Connecting to embedded MindsDB instance...")
+  # Connect through the HTTP API (useful behind HTTP-only proxies)
+  mindsdb-cli connect --protocol http --host localhost:47334
 
-			// MindsDB by default doesn't require authentication unless configured
-			// Only use provided credentials if they are explicitly given
+  # Connect over TLS, verifying the server against a private CA
+  mindsdb-cli connect --host mindsdb.example.com:5432 --user admin --sslmode verify-full --ssl-ca ca.pem
 
-			// Create embedded client
-			client, err = mindsdb.NewEmbeddedClient(user, pass)
-			if err != nil {
-				fmt.Printf("❌ Failed to connect to embedded MindsDB: %v\n", err)
-				fmt.Println("💡 Try 'mindsdb-cli start' first to ensure the container is running.")
-				return
-			}
+  # Save the connection settings as a named profile (passwords are not saved)
+  mindsdb-cli connect --host cloud.mindsdb.com --user your-email --password-file ~/.mindsdb-pass --save cloud`,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := connectConn.resolve(cmd)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
 
-			fmt.Printf("✅ Connected to embedded MindsDB!\n")
+		client, err := connectWithSettings(cmd, settings)
+		if err != nil {
+			return
+		}
+		if settings.Host != "" && !settings.Embedded {
+			fmt.Printf("✅ Connected to MindsDB at %s!\n", settings.Host)
 		} else {
-			// Validate external connection parameters
-			if host == "" || user == "" || pass == "" {
-				fmt.Println("❌ Username, password, and host are required for external connections.")
-				fmt.Println("   Use: mindsdb-cli connect --host <host> --user <username> --pass <password>")
-				return
-			}
-
-			// Create external client
-			client, err = mindsdb.NewClient(host, user, pass)
-			if err != nil {
-				fmt.Printf("❌ Failed to connect to MindsDB: %v\n", err)
-				return
-			}
-
-			fmt.Printf("✅ Connected to MindsDB at %s!\n", host)
+			fmt.Printf("✅ Connected to embedded MindsDB%s!\n", instanceSuffix(settings.Instance))
 		}
 
 		defer client.Close()
 
 		// Test the connection with a simple query
 		fmt.Println("\n🧪 Testing connection...")
-		rows, err := client.Query("SELECT 1 as test")
+		rows, err := client.Query(context.Background(), "SELECT 1 as test")
 		if err != nil {
 			fmt.Printf("⚠️  Connection established but query failed: %v\n", err)
 		} else {
@@ -73,6 +63,13 @@ Examples:
 			fmt.Println("✅ Connection test successful!")
 		}
 
+		if saveProfile != "" {
+			if err := saveConnectionProfile(saveProfile, settings); err != nil {
+				fmt.Printf("❌ Failed to save profile: %v\n", err)
+				return
+			}
+		}
+
 		fmt.Println("\n🚀 Ready to use MindsDB!")
 		fmt.Println("💡 Try these commands:")
 		fmt.Println("   mindsdb-cli list-models")
@@ -81,9 +78,32 @@ Examples:
 	},
 }
 
+// saveConnectionProfile stores the settings under name, making it the
+// active profile if none is active yet
+func saveConnectionProfile(name string, settings *connectionSettings) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	cfg.SetProfile(name, settings.profile())
+	if cfg.CurrentProfile == "" {
+		cfg.CurrentProfile = name
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n💾 Saved profile '%s' to %s\n", name, cfg.File())
+	if cfg.CurrentProfile == name {
+		fmt.Printf("   '%s' is the active profile\n", name)
+	} else {
+		fmt.Printf("   Use 'mindsdb-cli config use-profile %s' to make it the default\n", name)
+	}
+	return nil
+}
+
 func init() {
-	connectCmd.Flags().StringVarP(&host, "host", "H", "", "MindsDB host (e.g. localhost:47335)")
-	connectCmd.Flags().StringVarP(&user, "user", "u", "", "MindsDB username")
-	connectCmd.Flags().StringVarP(&pass, "pass", "p", "", "MindsDB password")
-	connectCmd.Flags().BoolVar(&embedded, "embedded", false, "Connect to embedded MindsDB instance")
+	connectConn.register(connectCmd)
+	connectCmd.Flags().StringVar(&saveProfile, "save", "", "Save the connection settings as a named profile")
 }
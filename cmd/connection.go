@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"mindsdb-go-cli/internal/config"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// profileName is set by the persistent --profile flag on the root command
+var profileName string
+
+// connectionFlags holds the connection flags shared by every command that
+// talks to MindsDB
+type connectionFlags struct {
+	credentialFlags
+	host     string
+	protocol string
+	database string
+	embedded bool
+	instance string
+	tls      mindsdb.TLSOptions
+}
+
+// connectionSettings is the result of merging flags over the active profile
+type connectionSettings struct {
+	mindsdb.ConnectOptions
+	Embedded     bool
+	Instance     string // Embedded instance name
+	PasswordFile string // Password file recorded in the profile, if any
+	Profile      string // Name of the profile the settings came from, if any
+}
+
+func (f *connectionFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.host, "host", "H", "", "MindsDB host (e.g. localhost:47335)")
+	f.credentialFlags.register(cmd, "")
+	cmd.Flags().StringVar(&f.protocol, "protocol", mindsdb.ProtocolPostgres, "Wire protocol for external connections: postgres, mysql, http")
+	cmd.Flags().StringVar(&f.database, "database", "", "Default database (defaults to mindsdb)")
+	cmd.Flags().BoolVar(&f.embedded, "embedded", false, "Use embedded MindsDB instance")
+	registerInstanceFlag(cmd, &f.instance)
+	cmd.Flags().StringVar(&f.tls.Mode, "sslmode", "", "TLS mode: disable, require, verify-ca, verify-full")
+	cmd.Flags().StringVar(&f.tls.CAFile, "ssl-ca", "", "PEM bundle of CAs used to verify the server")
+	cmd.Flags().StringVar(&f.tls.CertFile, "ssl-cert", "", "Client certificate for mutual TLS")
+	cmd.Flags().StringVar(&f.tls.KeyFile, "ssl-key", "", "Client private key for mutual TLS")
+	cmd.Flags().StringVar(&f.tls.ServerName, "ssl-server-name", "", "Server name to verify instead of the host name")
+}
+
+// resolve returns the connection settings for cmd. Values come from the
+// profile selected with --profile (or the config's current profile) and are
+// overridden by any flag set explicitly on the command line. When an external
+// instance needs a password that no source provides, the user is prompted.
+func (f *connectionFlags) resolve(cmd *cobra.Command) (*connectionSettings, error) {
+	settings := &connectionSettings{}
+	settings.Protocol = mindsdb.ProtocolPostgres
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	name := profileName
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	if name != "" {
+		profile, err := cfg.Profile(name)
+		if err != nil {
+			return nil, err
+		}
+		settings.Profile = name
+		settings.Host = profile.Host
+		settings.User = profile.User
+		settings.Database = profile.Database
+		settings.Embedded = profile.Embedded
+		settings.Instance = profile.Instance
+		settings.PasswordFile = profile.PasswordFile
+		settings.TLS = mindsdb.TLSOptions{
+			Mode:       profile.TLS.Mode,
+			CAFile:     profile.TLS.CAFile,
+			CertFile:   profile.TLS.CertFile,
+			KeyFile:    profile.TLS.KeyFile,
+			ServerName: profile.TLS.ServerName,
+		}
+		if profile.Protocol != "" {
+			settings.Protocol = profile.Protocol
+		}
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("host") {
+		settings.Host = f.host
+		settings.Embedded = false
+	}
+	if flags.Changed("embedded") {
+		settings.Embedded = f.embedded
+	}
+	if flags.Changed("instance") {
+		if err := mindsdb.ValidateInstanceName(f.instance); err != nil {
+			return nil, err
+		}
+		settings.Instance = f.instance
+		if !flags.Changed("host") {
+			settings.Embedded = true
+		}
+	}
+	if flags.Changed("user") {
+		settings.User = f.user
+	}
+	if f.passwordFile != "" {
+		settings.PasswordFile = f.passwordFile
+	}
+	if flags.Changed("protocol") {
+		settings.Protocol = f.protocol
+	}
+	if flags.Changed("database") {
+		settings.Database = f.database
+	}
+	if flags.Changed("sslmode") {
+		settings.TLS.Mode = f.tls.Mode
+	}
+	if flags.Changed("ssl-ca") {
+		settings.TLS.CAFile = f.tls.CAFile
+	}
+	if flags.Changed("ssl-cert") {
+		settings.TLS.CertFile = f.tls.CertFile
+	}
+	if flags.Changed("ssl-key") {
+		settings.TLS.KeyFile = f.tls.KeyFile
+	}
+	if flags.Changed("ssl-server-name") {
+		settings.TLS.ServerName = f.tls.ServerName
+	}
+
+	password, found, err := f.password(cmd, settings.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+	settings.Password = password
+
+	if !found && !settings.Embedded && settings.Host != "" && settings.User != "" {
+		if settings.Password, err = promptPassword(settings.User); err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
+
+// profile converts the settings into a profile that can be saved
+func (s *connectionSettings) profile() *config.Profile {
+	profile := &config.Profile{
+		User:         s.User,
+		Database:     s.Database,
+		Embedded:     s.Embedded,
+		PasswordFile: s.PasswordFile,
+	}
+	if s.Embedded {
+		profile.Instance = s.Instance
+	} else {
+		profile.Host = s.Host
+		profile.Protocol = s.Protocol
+		profile.TLS = config.TLS{
+			Mode:       s.TLS.Mode,
+			CAFile:     s.TLS.CAFile,
+			CertFile:   s.TLS.CertFile,
+			KeyFile:    s.TLS.KeyFile,
+			ServerName: s.TLS.ServerName,
+		}
+	}
+	return profile
+}
+
+// connectToMindsDB opens a connection using the resolved settings, falling
+// back to the embedded instance when no host is configured
+func connectToMindsDB(cmd *cobra.Command, flags *connectionFlags) (*mindsdb.MindsDBClient, error) {
+	settings, err := flags.resolve(cmd)
+	if err != nil {
+		color.Red("❌ %v", err)
+		return nil, err
+	}
+	return connectWithSettings(cmd, settings)
+}
+
+// connectWithSettings opens a connection using settings that were already
+// resolved, printing what went wrong on failure
+func connectWithSettings(cmd *cobra.Command, settings *connectionSettings) (*mindsdb.MindsDBClient, error) {
+	if settings.Profile != "" {
+		color.Blue("📋 Using profile '%s'", settings.Profile)
+	}
+
+	var client *mindsdb.MindsDBClient
+	var err error
+
+	if settings.Embedded {
+		color.Blue("🔗 Connecting to embedded MindsDB%s...", instanceSuffix(settings.Instance))
+		client, err = mindsdb.NewEmbeddedClient(mindsdb.EmbeddedOptions{
+			User:     settings.User,
+			Password: settings.Password,
+			Runtime:  runtimeName,
+			Instance: settings.Instance,
+		})
+		if err != nil {
+			color.Red("❌ Failed to connect to embedded MindsDB: %v", err)
+			color.Yellow("💡 Try 'mindsdb-cli start%s' first to ensure the container is running.", mindsdb.InstanceArg(settings.Instance))
+			return nil, err
+		}
+	} else if settings.Host != "" {
+		color.Blue("🔗 Connecting to MindsDB at %s...", settings.Host)
+		if settings.Protocol != mindsdb.ProtocolHTTP && (settings.User == "" || settings.Password == "") {
+			color.Red("❌ Username and password are required for external connections.")
+			fmt.Printf("   Use: mindsdb-cli %s --host <host> --user <user>\n", cmd.Name())
+			fmt.Printf("   The password is read from %s, --password-file, --password-stdin or a prompt\n", envPassword)
+			return nil, fmt.Errorf("missing credentials")
+		}
+		client, err = mindsdb.Connect(settings.ConnectOptions)
+		if err != nil {
+			color.Red("❌ Failed to connect to MindsDB: %v", err)
+			return nil, err
+		}
+	} else {
+		// Default to embedded mode
+		color.Blue("🔗 Connecting to embedded MindsDB (default)...")
+		client, err = mindsdb.NewEmbeddedClient(mindsdb.EmbeddedOptions{Runtime: runtimeName})
+		if err != nil {
+			color.Red("❌ Failed to connect to embedded MindsDB: %v", err)
+			color.Yellow("💡 Try 'mindsdb-cli start' first or use --host for external connections.")
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// progressToStderr sends progress messages, including the ones printed
+// while connecting or starting the embedded instance, to stderr until the
+// returned function is called, keeping stdout for machine-readable output
+func progressToStderr() (restore func()) {
+	stdout, colorOutput := os.Stdout, color.Output
+	os.Stdout, color.Output = os.Stderr, color.Error
+	return func() {
+		os.Stdout, color.Output = stdout, colorOutput
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"mindsdb-go-cli/internal/config"
+	"mindsdb-go-cli/internal/mindsdb"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// withProfiles saves profiles to a temporary config file, selecting current
+func withProfiles(t *testing.T, current string, profiles map[string]*config.Profile) {
+	t.Helper()
+	t.Setenv(config.EnvConfigPath, filepath.Join(t.TempDir(), "config.yaml"))
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, profile := range profiles {
+		cfg.SetProfile(name, profile)
+	}
+	cfg.CurrentProfile = current
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// resolveArgs parses args as the connection flags of a command and resolves
+// them, with --profile set to profile
+func resolveArgs(t *testing.T, profile string, args ...string) (*connectionSettings, error) {
+	t.Helper()
+	previous := profileName
+	profileName = profile
+	t.Cleanup(func() { profileName = previous })
+
+	var flags connectionFlags
+	cmd := &cobra.Command{Use: "query"}
+	flags.register(cmd)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	return flags.resolve(cmd)
+}
+
+func TestResolveProfiles(t *testing.T) {
+	t.Setenv(envPassword, "secret")
+	withProfiles(t, "prod", map[string]*config.Profile{
+		"prod": {
+			Host:     "db.example.com:5432",
+			User:     "admin",
+			Database: "sales",
+			TLS:      config.TLS{Mode: mindsdb.SSLModeVerifyFull, CAFile: "/etc/ssl/ca.pem"},
+		},
+		"local": {Embedded: true, Instance: "scratch"},
+		"web":   {Host: "https://cloud.example.com", Protocol: mindsdb.ProtocolHTTP},
+	})
+
+	tests := []struct {
+		name    string
+		profile string
+		args    []string
+		check   func(t *testing.T, s *connectionSettings)
+	}{
+		{
+			name: "current profile",
+			check: func(t *testing.T, s *connectionSettings) {
+				if s.Profile != "prod" || s.Host != "db.example.com:5432" || s.User != "admin" || s.Database != "sales" {
+					t.Errorf("settings = %+v", s)
+				}
+				if s.Protocol != mindsdb.ProtocolPostgres || s.TLS.Mode != mindsdb.SSLModeVerifyFull || s.TLS.CAFile != "/etc/ssl/ca.pem" {
+					t.Errorf("protocol = %s, TLS = %+v", s.Protocol, s.TLS)
+				}
+				if s.Password != "secret" {
+					t.Errorf("password not taken from %s", envPassword)
+				}
+			},
+		},
+		{
+			name: "flags override the profile",
+			args: []string{"--user", "analyst", "--database", "mindsdb", "--protocol", "mysql", "--sslmode", "require", "--ssl-server-name", "mindsdb.internal"},
+			check: func(t *testing.T, s *connectionSettings) {
+				if s.Host != "db.example.com:5432" || s.User != "analyst" || s.Database != "mindsdb" || s.Protocol != mindsdb.ProtocolMySQL {
+					t.Errorf("settings = %+v", s)
+				}
+				// Flags replace single values and keep the rest of the profile
+				if s.TLS.Mode != mindsdb.SSLModeRequire || s.TLS.ServerName != "mindsdb.internal" || s.TLS.CAFile != "/etc/ssl/ca.pem" {
+					t.Errorf("TLS = %+v", s.TLS)
+				}
+			},
+		},
+		{
+			name:    "selected profile",
+			profile: "web",
+			check: func(t *testing.T, s *connectionSettings) {
+				if s.Profile != "web" || s.Host != "https://cloud.example.com" || s.Protocol != mindsdb.ProtocolHTTP || s.User != "" {
+					t.Errorf("settings = %+v", s)
+				}
+			},
+		},
+		{
+			name:    "host flag leaves an embedded profile",
+			profile: "local",
+			args:    []string{"--host", "localhost:47335"},
+			check: func(t *testing.T, s *connectionSettings) {
+				if s.Embedded || s.Host != "localhost:47335" {
+					t.Errorf("settings = %+v", s)
+				}
+			},
+		},
+		{
+			name: "instance flag selects embedded",
+			args: []string{"--instance", "scratch"},
+			check: func(t *testing.T, s *connectionSettings) {
+				if !s.Embedded || s.Instance != "scratch" {
+					t.Errorf("settings = %+v", s)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := resolveArgs(t, tt.profile, tt.args...)
+			if err != nil {
+				t.Fatalf("resolve() error = %v", err)
+			}
+			tt.check(t, settings)
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	t.Setenv(envPassword, "secret")
+	withProfiles(t, "", map[string]*config.Profile{"prod": {Host: "db.example.com"}})
+
+	if _, err := resolveArgs(t, "staging"); err == nil || !strings.Contains(err.Error(), `profile "staging" not found`) {
+		t.Errorf("resolve() with an unknown profile error = %v", err)
+	}
+	if _, err := resolveArgs(t, "", "--instance", "Bad Name"); err == nil {
+		t.Error("resolve() with an invalid instance name succeeded")
+	}
+}
+
+func TestResolveWithoutConfig(t *testing.T) {
+	t.Setenv(config.EnvConfigPath, filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Setenv(envPassword, "")
+
+	settings, err := resolveArgs(t, "")
+	if err != nil {
+		t.Fatalf("resolve() error = %v", err)
+	}
+	if settings.Profile != "" || settings.Host != "" || settings.Embedded || settings.Protocol != mindsdb.ProtocolPostgres {
+		t.Errorf("settings = %+v", settings)
+	}
+}
@@ -2,23 +2,127 @@ package cmd
 
 import (
 	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var modelName, fromTable, predictColumn string
+var createModelConn connectionFlags
+var createModelProject string
+var createModelEngine string
+var createModelUsing []string
+var createModelSelect string
+var createModelOrderBy string
+var createModelGroupBy []string
+var createModelWindow, createModelHorizon int
+var createModelWait bool
+var createModelWaitTimeout time.Duration
 
 var createModelCmd = &cobra.Command{
 	Use:   "create-model",
 	Short: "Create a new model",
+	Long: `Create and train a model with a CREATE MODEL statement.
+
+--from names the data to train on: either integration.table to train on a
+whole table, or an integration together with --select for a custom training
+query that runs against it. Models of engines that need no training data,
+such as LLM engines, can omit --from.
+
+Engine parameters are given with repeatable --using key=value flags. Numbers,
+booleans and JSON objects or arrays are passed as they are, other values as
+strings.
+
+Time-series models order their rows with --order-by, can partition them with
+--group-by, and look back --window rows to predict --horizon rows.
+
+All names are quoted, so they may contain spaces or reserved words. Training
+runs in the background; check its progress with 'mindsdb-cli list-models' or
+'mindsdb-cli models watch <name>'. With --wait the command follows training
+itself and exits with a non-zero status if it fails, like 'models watch'.
+Invalid options, connection failures and a rejected statement always exit
+with a non-zero status.
+
+Examples:
+  mindsdb-cli create-model --name home_rentals_model --from example_db.demo_data.home_rentals --predict rental_price
+  mindsdb-cli create-model --name churn --from my_pg --select "SELECT * FROM customers WHERE active" --predict churned
+  mindsdb-cli create-model --project sales --name forecast --from my_pg.sales --predict amount \
+      --order-by saledate --group-by region --window 12 --horizon 4
+  mindsdb-cli create-model --name summarizer --predict summary --engine openai \
+      --using model_name=gpt-4o --using "prompt_template=Summarize: {{text}}"
+  mindsdb-cli create-model --name my_model --from my_db.data --predict y --wait --wait-timeout 1h`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("Creating model '%s' from '%s' to predict '%s'\n", modelName, fromTable, predictColumn)
-		// Lógica para crear modelo
+		opts := mindsdb.CreateModelOptions{
+			Project: createModelProject,
+			Name:    modelName,
+			From:    fromTable,
+			Select:  createModelSelect,
+			Predict: predictColumn,
+			Engine:  createModelEngine,
+			Using:   createModelUsing,
+			OrderBy: createModelOrderBy,
+			GroupBy: createModelGroupBy,
+			Window:  createModelWindow,
+			Horizon: createModelHorizon,
+		}
+		// Build the statement first so mistakes are reported before connecting
+		statement, err := opts.Statement()
+		if err != nil {
+			color.Red("❌ %v", err)
+			os.Exit(1)
+		}
+
+		client, err := connectToMindsDB(cmd, &createModelConn)
+		if err != nil {
+			os.Exit(1)
+		}
+		defer client.Close()
+
+		color.Cyan("🤖 Creating model '%s'...", modelName)
+		fmt.Println(statement)
+		fmt.Println()
+
+		ctx, stop := statementContext()
+		defer stop()
+		if err := client.Exec(ctx, statement); err != nil {
+			color.Red("❌ Failed to create model: %v", describeQueryError(ctx, err))
+			client.Close()
+			os.Exit(1)
+		}
+
+		color.Green("✅ Model '%s' created, training has started", modelName)
+		if !createModelWait {
+			fmt.Printf("💡 Use 'mindsdb-cli models watch %s' to follow its progress\n", modelName)
+			return
+		}
+
+		// With --wait a failed training fails the command too, so pipelines
+		// can gate on the model being trained
+		stop()
+		if !watchModel(client, createModelProject, modelName, createModelWaitTimeout) {
+			client.Close()
+			os.Exit(1)
+		}
 	},
 }
 
 func init() {
+	createModelConn.register(createModelCmd)
 	createModelCmd.Flags().StringVar(&modelName, "name", "", "Model name")
-	createModelCmd.Flags().StringVar(&fromTable, "from", "", "Source table")
+	createModelCmd.Flags().StringVar(&fromTable, "from", "", "Training data: integration.table, or an integration together with --select")
 	createModelCmd.Flags().StringVar(&predictColumn, "predict", "", "Target column")
+	createModelCmd.Flags().StringVar(&createModelProject, "project", "", "Project to create the model in (default MindsDB's default project)")
+	createModelCmd.Flags().StringVar(&createModelEngine, "engine", "", "ML engine, e.g. lightwood or openai (default MindsDB's default engine)")
+	createModelCmd.Flags().StringArrayVar(&createModelUsing, "using", nil, "Engine parameter as key=value (repeatable)")
+	createModelCmd.Flags().StringVar(&createModelSelect, "select", "", "Training query to run against the --from integration")
+	createModelCmd.Flags().StringVar(&createModelOrderBy, "order-by", "", "Time-series: column that orders the rows")
+	createModelCmd.Flags().StringSliceVar(&createModelGroupBy, "group-by", nil, "Time-series: columns that partition the rows (repeatable or comma-separated)")
+	createModelCmd.Flags().IntVar(&createModelWindow, "window", 0, "Time-series: number of past rows the model looks at")
+	createModelCmd.Flags().IntVar(&createModelHorizon, "horizon", 0, "Time-series: number of future rows to predict")
+	createModelCmd.Flags().BoolVar(&createModelWait, "wait", false, "Wait until training completes and fail if it fails")
+	createModelCmd.Flags().DurationVar(&createModelWaitTimeout, "wait-timeout", 0, "With --wait, give up after this long (default wait until training finishes)")
 }
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// envPassword is the environment variable read when no other password
+// source is given
+const envPassword = "MINDSDB_PASSWORD"
+
+// passwordInput is where --password-stdin reads from
+var passwordInput io.Reader = os.Stdin
+
+// credentialFlags holds the username and the different ways a password can
+// be supplied. Passing --pass on the command line is still supported but
+// discouraged because it ends up in shell history, process lists and CI logs.
+type credentialFlags struct {
+	user          string
+	pass          string
+	passwordFile  string
+	passwordStdin bool
+}
+
+// register adds the credential flags to cmd. usage describes when the
+// credentials are needed and is appended to the flag descriptions.
+func (f *credentialFlags) register(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringVarP(&f.user, "user", "u", "", "MindsDB username"+usage)
+	cmd.Flags().StringVarP(&f.pass, "pass", "p", "", "MindsDB password"+usage+" (insecure, prefer "+envPassword+" or --password-file)")
+	cmd.Flags().StringVar(&f.passwordFile, "password-file", "", "Read the MindsDB password from a file")
+	cmd.Flags().BoolVar(&f.passwordStdin, "password-stdin", false, "Read the MindsDB password from stdin")
+}
+
+// password returns the password from the first source that provides one:
+// --pass, --password-file, --password-stdin, then MINDSDB_PASSWORD, and
+// finally the fallback file (usually the profile's password_file). The
+// boolean reports whether any source was found, so an explicitly empty
+// password can be told apart from a missing one.
+func (f *credentialFlags) password(cmd *cobra.Command, fallbackFile string) (string, bool, error) {
+	flags := cmd.Flags()
+
+	if flags.Changed("pass") {
+		fmt.Fprintf(os.Stderr, "%s\n", color.YellowString("⚠️  --pass exposes the password in shell history and process lists; prefer %s, --password-file or --password-stdin", envPassword))
+		return f.pass, true, nil
+	}
+
+	if f.passwordFile != "" {
+		pass, err := readPasswordFile(f.passwordFile)
+		return pass, err == nil, err
+	}
+
+	if f.passwordStdin {
+		pass, err := readLine(passwordInput)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+		return pass, true, nil
+	}
+
+	if pass, ok := os.LookupEnv(envPassword); ok {
+		return pass, true, nil
+	}
+
+	if fallbackFile != "" {
+		pass, err := readPasswordFile(fallbackFile)
+		return pass, err == nil, err
+	}
+
+	return "", false, nil
+}
+
+// readPasswordFile reads a password stored on the first line of a file
+func readPasswordFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimRight(line, "\r"), nil
+}
+
+// readLine reads the first line of r without buffering, so whatever follows
+// the password on stdin is left for the command
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimRight(string(line), "\r"), nil
+}
+
+// promptPassword asks for a password without echoing it. It fails when
+// stdin is not a terminal so scripts never hang waiting for input.
+func promptPassword(user string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no password given for user '%s' (use %s, --password-file or --password-stdin)", user, envPassword)
+	}
+
+	fmt.Fprintf(os.Stderr, "🔑 Password for %s: ", user)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(data), nil
+}
+
+// redact hides a secret for display
+func redact(secret string) string {
+	if secret == "" {
+		return "(empty)"
+	}
+	return "********"
+}
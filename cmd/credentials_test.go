@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/spf13/cobra"
+)
+
+// writeFile writes content to a file in a temporary directory
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPasswordSources(t *testing.T) {
+	flagFile := writeFile(t, "flag", "from-file\n")
+	profileFile := writeFile(t, "profile", "from-profile")
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      *string
+		stdin    string
+		fallback string
+		want     string
+		found    bool
+	}{
+		{name: "--pass first", args: []string{"--pass", "from-flag", "--password-file", flagFile, "--password-stdin"}, env: ptr("from-env"), stdin: "from-stdin\n", want: "from-flag", found: true},
+		{name: "explicitly empty --pass", args: []string{"--pass", ""}, env: ptr("from-env"), want: "", found: true},
+		{name: "--password-file before stdin", args: []string{"--password-file", flagFile, "--password-stdin"}, stdin: "from-stdin\n", want: "from-file", found: true},
+		{name: "--password-stdin before env", args: []string{"--password-stdin"}, env: ptr("from-env"), stdin: "from-stdin\n", want: "from-stdin", found: true},
+		{name: "env before profile file", env: ptr("from-env"), fallback: profileFile, want: "from-env", found: true},
+		{name: "empty env is a password", env: ptr(""), fallback: profileFile, want: "", found: true},
+		{name: "profile file last", fallback: profileFile, want: "from-profile", found: true},
+		{name: "no source", want: "", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != nil {
+				t.Setenv(envPassword, *tt.env)
+			} else {
+				unsetEnv(t, envPassword)
+			}
+			previous := passwordInput
+			passwordInput = strings.NewReader(tt.stdin)
+			t.Cleanup(func() { passwordInput = previous })
+
+			var flags credentialFlags
+			cmd := &cobra.Command{Use: "query"}
+			flags.register(cmd, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			got, found, err := flags.password(cmd, tt.fallback)
+			if err != nil {
+				t.Fatalf("password() error = %v", err)
+			}
+			if got != tt.want || found != tt.found {
+				t.Errorf("password() = %q, %v; want %q, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestPasswordFileErrors(t *testing.T) {
+	unsetEnv(t, envPassword)
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	var flags credentialFlags
+	cmd := &cobra.Command{Use: "query"}
+	flags.register(cmd, "")
+	if err := cmd.ParseFlags([]string{"--password-file", missing}); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, err := flags.password(cmd, ""); err == nil || found || !strings.Contains(err.Error(), "failed to read password file") {
+		t.Errorf("password() = found %v, error %v", found, err)
+	}
+
+	var noFlags credentialFlags
+	cmd = &cobra.Command{Use: "query"}
+	noFlags.register(cmd, "")
+	if _, found, err := noFlags.password(cmd, missing); err == nil || found {
+		t.Errorf("password() with a missing profile file = found %v, error %v", found, err)
+	}
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"secret", "secret"},
+		{"secret\n", "secret"},
+		{"secret\r\n", "secret"},
+		{"first\nsecond\n", "first"},
+		{" spaced secret \n", " spaced secret "},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := readPasswordFile(writeFile(t, "password", tt.content))
+		if err != nil || got != tt.want {
+			t.Errorf("readPasswordFile(%q) = %q, %v; want %q", tt.content, got, err, tt.want)
+		}
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		rest  string
+	}{
+		{"secret\nSELECT 1;\n", "secret", "SELECT 1;\n"},
+		{"secret\r\n", "secret", ""},
+		{"secret", "secret", ""},
+		{"\nnext", "", "next"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		// Only the first line is consumed, whatever follows stays unread
+		r := strings.NewReader(tt.input)
+		got, err := readLine(r)
+		if err != nil || got != tt.want {
+			t.Errorf("readLine(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+		if rest, _ := io.ReadAll(r); string(rest) != tt.rest {
+			t.Errorf("readLine(%q) left %q, want %q", tt.input, rest, tt.rest)
+		}
+	}
+
+	if _, err := readLine(iotest.ErrReader(io.ErrUnexpectedEOF)); err != io.ErrUnexpectedEOF {
+		t.Errorf("readLine() error = %v, want the reader's error", err)
+	}
+}
+
+func TestPromptPasswordWithoutTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	// Scripts get an error instead of a prompt that never returns
+	_, err = promptPassword("admin")
+	if err == nil || !strings.Contains(err.Error(), "no password given for user 'admin'") {
+		t.Errorf("promptPassword() error = %v", err)
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+// unsetEnv removes an environment variable for the duration of the test
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var imageSaveVersion string
+
+var imageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Manage the embedded MindsDB image",
+	Long: `Manage the MindsDB image used for embedded instances.
+
+Use 'image save' on a machine with registry access to produce an archive,
+copy it to an offline machine and start MindsDB there with
+'mindsdb-cli start --image-archive <file>'.
+
+Examples:
+  mindsdb-cli image save
+  mindsdb-cli image save --version v25.1.2 ./mindsdb-v25.1.2.tar`,
+}
+
+var imageSaveCmd = &cobra.Command{
+	Use:   "save [file]",
+	Short: "Save the MindsDB image to a tar archive",
+	Long: `Save the MindsDB image to a tar archive that 'mindsdb-cli start
+--image-archive' can load. The image is pulled first if it is not present
+locally. Without a file name the archive is written to mindsdb-<version>.tar
+in the current directory.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mindsdb.ValidateVersion(imageSaveVersion); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+
+		file := fmt.Sprintf("mindsdb-%s.tar", imageSaveVersion)
+		if len(args) == 1 {
+			file = args[0]
+		}
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		client := &mindsdb.MindsDBClient{Runtime: rt}
+		fmt.Printf("💾 Saving %s to %s...\n", mindsdb.ImageRef(imageSaveVersion), file)
+		_, err = client.SaveImage(context.Background(), imageSaveVersion, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(file)
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		if info, err := os.Stat(file); err == nil {
+			fmt.Printf("✅ Image saved to %s (%.1f MB)\n", file, float64(info.Size())/(1<<20))
+		}
+		fmt.Printf("💡 Use 'mindsdb-cli start --image-archive %s' on the target machine\n", file)
+	},
+}
+
+func init() {
+	imageCmd.AddCommand(imageSaveCmd)
+	imageSaveCmd.Flags().StringVar(&imageSaveVersion, "version", mindsdb.DefaultVersion, "MindsDB image tag to save")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// registerInstanceFlag adds the --instance flag used to pick an embedded
+// instance
+func registerInstanceFlag(cmd *cobra.Command, instance *string) {
+	cmd.Flags().StringVar(instance, "instance", "", "Embedded instance name (default \""+mindsdb.DefaultInstance+"\")")
+}
+
+// instanceSuffix describes a non-default instance in status messages
+func instanceSuffix(instance string) string {
+	if mindsdb.InstanceArg(instance) == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (instance '%s')", instance)
+}
+
+var instancesCmd = &cobra.Command{
+	Use:   "instances",
+	Short: "Manage embedded MindsDB instances",
+	Long: `Manage embedded MindsDB instances.
+
+Each instance runs in its own container with its own ports and data volume.
+The default instance uses the container mindsdb-cli-embedded; an instance
+named scratch uses mindsdb-cli-embedded-scratch. Pass --instance <name> to
+start, stop, status, query, connect and the other commands to select one.
+
+Examples:
+  mindsdb-cli start --instance scratch
+  mindsdb-cli instances list
+  mindsdb-cli query --instance scratch "SHOW DATABASES"
+  mindsdb-cli stop --instance scratch --remove`,
+}
+
+var instancesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List embedded instances and their state",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		instances, err := mindsdb.ListInstances(ctx, rt)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		if len(instances) == 0 {
+			fmt.Println("ℹ️  No embedded instances found")
+			fmt.Println("💡 Use 'mindsdb-cli start [--instance <name>]' to create one")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "INSTANCE\tCONTAINER\tSTATE\tIMAGE\tWEB UI\tMYSQL")
+		for _, instance := range instances {
+			image, _ := mindsdb.ContainerImage(instance.Container)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\thttp://localhost:%s\tlocalhost:%s\n",
+				instance.Name,
+				instance.Container.Name,
+				instance.Container.Status,
+				image,
+				instance.Ports.HTTP,
+				instance.Ports.MySQL,
+			)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	instancesCmd.AddCommand(instancesListCmd)
+}
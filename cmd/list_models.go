@@ -2,15 +2,97 @@ package cmd
 
 import (
 	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"slices"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var listModelsConn connectionFlags
+var listModelsProject string
+var listModelsStatus string
+var listModelsEngine string
+var listModelsFormat string
+
 var listModelsCmd = &cobra.Command{
 	Use:   "list-models",
 	Short: "List all available models",
+	Long: `List the models of all projects, or of one project with --project.
+
+For each model the project, name, engine, status, version, accuracy,
+predicted column and training time are shown. Results are rendered like
+'query' results, as a table, JSON or CSV.
+
+Examples:
+  mindsdb-cli list-models
+  mindsdb-cli list-models --project mindsdb
+  mindsdb-cli list-models --status training
+  mindsdb-cli list-models --engine lightwood --format json
+  mindsdb-cli list-models --profile prod --format csv > models.csv`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Listing all models...")
-		// Lógica para listar modelos
+		listModelsStatus = strings.ToLower(listModelsStatus)
+		if listModelsStatus != "" && !slices.Contains(mindsdb.ModelStatuses, listModelsStatus) {
+			color.Red("❌ Invalid status %q (use %s)", listModelsStatus, strings.Join(mindsdb.ModelStatuses, ", "))
+			return
+		}
+		if !slices.Contains([]string{"table", "json", "csv"}, listModelsFormat) {
+			color.Red("❌ Invalid format %q (use table, json or csv)", listModelsFormat)
+			return
+		}
+
+		// JSON and CSV must be the only thing on stdout, so the messages
+		// printed while connecting go to stderr
+		restoreStdout := func() {}
+		if listModelsFormat != "table" {
+			restoreStdout = progressToStderr()
+		}
+		client, err := connectToMindsDB(cmd, &listModelsConn)
+		restoreStdout()
+		if err != nil {
+			return
+		}
+		defer client.Close()
+
+		if listModelsFormat == "table" {
+			if listModelsProject != "" {
+				color.Cyan("🤖 Listing models in project '%s'...", listModelsProject)
+			} else {
+				color.Cyan("🤖 Listing models in all projects...")
+			}
+			fmt.Println()
+		}
+
+		ctx, stop := statementContext()
+		defer stop()
+		query := mindsdb.ListModelsQuery(mindsdb.ModelFilter{
+			Project: listModelsProject,
+			Status:  listModelsStatus,
+			Engine:  listModelsEngine,
+		})
+		columns, rows, err := collectRows(ctx, client, query)
+		if err != nil {
+			color.Red("❌ Failed to list models: %v", describeQueryError(ctx, err))
+			return
+		}
+
+		if len(rows) == 0 && listModelsFormat == "table" {
+			color.Yellow("📝 No models found")
+			fmt.Println("   Create one with 'mindsdb-cli create-model'")
+			return
+		}
+		if err := renderRows(listModelsFormat, columns, rows); err != nil {
+			color.Red("❌ %v", err)
+		}
 	},
 }
+
+func init() {
+	listModelsConn.register(listModelsCmd)
+	listModelsCmd.Flags().StringVar(&listModelsProject, "project", "", "Only list models of this project (default all projects)")
+	listModelsCmd.Flags().StringVar(&listModelsStatus, "status", "", "Only list models with this status: "+strings.Join(mindsdb.ModelStatuses, ", "))
+	listModelsCmd.Flags().StringVar(&listModelsEngine, "engine", "", "Only list models trained with this ML engine, e.g. lightwood")
+	listModelsCmd.Flags().StringVar(&listModelsFormat, "format", "table", "Output format: table, json, csv")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+	"os/signal"
+	"regexp"
+
+	"github.com/spf13/cobra"
+)
+
+var logsInstance string
+var logsOpts mindsdb.LogsOptions
+var logsLevel string
+var logsGrep string
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the logs of the embedded MindsDB container",
+	Long: `Show the logs of an embedded MindsDB instance.
+
+--level keeps only entries of that level or higher (debug, info, warning,
+error, critical). Tracebacks and other continuation lines are kept together
+with the entry they belong to. --grep keeps only entries matching a regular
+expression, e.g. the name of a model or handler; prefix it with (?i) for a
+case-insensitive match.
+
+Examples:
+  mindsdb-cli logs --tail 100
+  mindsdb-cli logs --follow --level warning
+  mindsdb-cli logs --since 30m --level error --grep "home_rentals_model"
+  mindsdb-cli logs --instance scratch --grep "(?i)postgres_handler"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mindsdb.ValidateInstanceName(logsInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+
+		filter := mindsdb.LogFilter{MinLevel: mindsdb.LevelDebug}
+		if logsLevel != "" {
+			level, err := mindsdb.ParseLogLevel(logsLevel)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				return
+			}
+			filter.MinLevel = level
+		}
+		if logsGrep != "" {
+			pattern, err := regexp.Compile(logsGrep)
+			if err != nil {
+				fmt.Printf("❌ Invalid --grep pattern: %v\n", err)
+				return
+			}
+			filter.Grep = pattern
+		}
+
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+		client := &mindsdb.MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: logsInstance}
+
+		// Stop following on Ctrl-C
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		logs, err := client.ContainerLogs(ctx, logsOpts)
+		if err != nil {
+			fmt.Printf("❌ Failed to read logs: %v\n", err)
+			return
+		}
+		defer logs.Close()
+
+		if err := filter.Copy(os.Stdout, logs); err != nil && ctx.Err() == nil && !errors.Is(err, context.Canceled) {
+			fmt.Printf("❌ Failed to read logs: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	registerInstanceFlag(logsCmd, &logsInstance)
+	logsCmd.Flags().BoolVarP(&logsOpts.Follow, "follow", "f", false, "Keep streaming new log lines")
+	logsCmd.Flags().StringVar(&logsOpts.Since, "since", "", "Show logs since a timestamp (2024-01-02T15:04:05Z) or duration (10m)")
+	logsCmd.Flags().StringVar(&logsOpts.Tail, "tail", "", "Number of lines to show from the end of the logs")
+	logsCmd.Flags().BoolVarP(&logsOpts.Timestamps, "timestamps", "t", false, "Prefix lines with the runtime's timestamps")
+	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Minimum level to show: debug, info, warning, error, critical")
+	logsCmd.Flags().StringVar(&logsGrep, "grep", "", "Only show entries matching this regular expression")
+}
@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var modelsConn connectionFlags
+var modelsProject string
+var modelsWatchTimeout time.Duration
+var modelsDescribeOutput string
+
+// modelPollInterval is how often the training status is checked
+const modelPollInterval = 2 * time.Second
+
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "Inspect ML models",
+	Long: `Inspect the ML models of a MindsDB instance.
+
+Examples:
+  mindsdb-cli models watch home_rentals_model
+  mindsdb-cli models watch forecast --project sales --timeout 30m
+  mindsdb-cli models describe home_rentals_model
+  mindsdb-cli models describe forecast --project sales --output json`,
+}
+
+var modelsWatchCmd = &cobra.Command{
+	Use:   "watch <name>",
+	Short: "Follow a model's training until it completes or fails",
+	Long: `Poll a model's status until training finishes, showing a spinner with
+the status and the elapsed time.
+
+The command exits with status 0 when the model is complete, and with a
+non-zero status and MindsDB's error message when training fails, the model
+does not exist or --timeout expires. Use it in pipelines to gate on training
+success.
+
+Examples:
+  mindsdb-cli models watch home_rentals_model
+  mindsdb-cli models watch forecast --project sales --timeout 30m`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := connectToMindsDB(cmd, &modelsConn)
+		if err != nil {
+			os.Exit(1)
+		}
+		defer client.Close()
+
+		if !watchModel(client, modelsProject, args[0], modelsWatchTimeout) {
+			client.Close()
+			os.Exit(1)
+		}
+	},
+}
+
+var modelsDescribeCmd = &cobra.Command{
+	Use:   "describe <name>",
+	Short: "Show a model's features, accuracy, mixers and training options",
+	Long: `Describe a trained model using MindsDB's DESCRIBE statements.
+
+The description has four sections:
+  info      Status, accuracy, predicted column and training options
+  features  Column types, encoders and roles
+  model     Candidate mixers, their accuracy and which one was selected
+  ensemble  How the mixers were combined
+
+Only some engines, such as Lightwood, provide the features, model and
+ensemble sections; for other engines they are reported as unavailable.
+
+With --output json the sections are printed as one JSON document for
+tooling: info as an object, the other sections as arrays of objects, and
+values holding JSON (such as training options) as nested JSON. Sections
+that could not be read are listed under "errors". The command exits with a
+non-zero status if the model cannot be described at all.
+
+Examples:
+  mindsdb-cli models describe home_rentals_model
+  mindsdb-cli models describe forecast --project sales --output json | jq .info.accuracy`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if modelsDescribeOutput != "text" && modelsDescribeOutput != "json" {
+			color.Red("❌ Invalid output format %q (use text or json)", modelsDescribeOutput)
+			os.Exit(1)
+		}
+		project := modelsProject
+		if project == "" {
+			project = mindsdb.DefaultProject
+		}
+		name := args[0]
+
+		// The JSON document must be the only thing on stdout, so the
+		// messages printed while connecting go to stderr
+		restoreStdout := func() {}
+		if modelsDescribeOutput == "json" {
+			restoreStdout = progressToStderr()
+		}
+		client, err := connectToMindsDB(cmd, &modelsConn)
+		restoreStdout()
+		if err != nil {
+			os.Exit(1)
+		}
+		defer client.Close()
+
+		ctx, stop := statementContext()
+		defer stop()
+
+		sections := map[string]*describeSection{}
+		for _, section := range mindsdb.DescribeSections {
+			columns, rows, err := collectRows(ctx, client, mindsdb.DescribeModelQuery(project, name, section))
+			if err != nil {
+				err = describeQueryError(ctx, err)
+			}
+			sections[section] = &describeSection{columns: columns, rows: rows, err: err}
+		}
+		if info := sections[mindsdb.DescribeInfo]; info.err != nil || len(info.rows) == 0 {
+			if info.err == nil {
+				info.err = fmt.Errorf("model not found")
+			}
+			fmt.Fprintln(os.Stderr, color.RedString("❌ Failed to describe model %s.%s: %v", project, name, info.err))
+			client.Close()
+			os.Exit(1)
+		}
+
+		if modelsDescribeOutput == "json" {
+			printDescriptionJSON(project, name, sections)
+			return
+		}
+		printDescriptionText(project, name, sections)
+	},
+}
+
+// describeSection is the result of one DESCRIBE statement
+type describeSection struct {
+	columns []string
+	rows    [][]string
+	err     error
+}
+
+// describeTitles are the headings of the sections in text output
+var describeTitles = map[string]string{
+	mindsdb.DescribeInfo:     "ℹ️  Info",
+	mindsdb.DescribeFeatures: "📋 Features",
+	mindsdb.DescribeModel:    "🎯 Model (mixers and accuracy)",
+	mindsdb.DescribeEnsemble: "🧩 Ensemble",
+}
+
+// printDescriptionText prints each section under a heading: single rows as
+// a list of fields, several rows as a table
+func printDescriptionText(project, name string, sections map[string]*describeSection) {
+	color.New(color.FgHiMagenta, color.Bold).Printf("🤖 Model %s.%s\n", project, name)
+
+	for _, key := range mindsdb.DescribeSections {
+		section := sections[key]
+		fmt.Println()
+		color.New(color.FgHiCyan, color.Bold).Println(describeTitles[key])
+
+		switch {
+		case section.err != nil:
+			color.Yellow("   Not available: %v", section.err)
+		case len(section.rows) == 0:
+			color.Yellow("   Nothing to show")
+		case len(section.rows) == 1:
+			printFields(section.columns, section.rows[0])
+		default:
+			colWidths := calculateColumnWidths(section.columns, section.rows, getTerminalWidth()-len(section.columns)*3-1)
+			printTable(section.columns, section.rows, colWidths)
+		}
+	}
+}
+
+// printFields prints one row as "column: value" lines, indenting values
+// that hold JSON
+func printFields(columns []string, row []string) {
+	width := 0
+	for _, column := range columns {
+		width = max(width, len(column))
+	}
+	for i, column := range columns {
+		value := row[i]
+		if raw := jsonValue(value); raw != nil {
+			var indented bytes.Buffer
+			if json.Indent(&indented, raw, "   ", "  ") == nil {
+				value = indented.String()
+			}
+		}
+		fmt.Printf("   %s: %s\n", color.New(color.FgHiBlue, color.Bold).Sprintf("%-*s", width, column), value)
+	}
+}
+
+// printDescriptionJSON prints the sections as one JSON document
+func printDescriptionJSON(project, name string, sections map[string]*describeSection) {
+	document := map[string]interface{}{"project": project, "name": name}
+	errs := map[string]string{}
+	for _, key := range mindsdb.DescribeSections {
+		section := sections[key]
+		if section.err != nil {
+			errs[key] = section.err.Error()
+			continue
+		}
+		objects := make([]map[string]interface{}, len(section.rows))
+		for i, row := range section.rows {
+			objects[i] = rowObject(section.columns, row)
+		}
+		if key == mindsdb.DescribeInfo {
+			document[key] = objects[0]
+		} else {
+			document[key] = objects
+		}
+	}
+	if len(errs) > 0 {
+		document["errors"] = errs
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		color.Red("❌ %v", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+// rowObject turns a row into an object keyed by lower-case column names.
+// NULLs become null and values holding JSON are nested as JSON.
+func rowObject(columns []string, row []string) map[string]interface{} {
+	object := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		var value interface{} = row[i]
+		if row[i] == "NULL" {
+			value = nil
+		} else if raw := jsonValue(row[i]); raw != nil {
+			value = raw
+		}
+		object[strings.ToLower(column)] = value
+	}
+	return object
+}
+
+// jsonValue returns value as raw JSON if it holds a JSON object or array
+func jsonValue(value string) json.RawMessage {
+	trimmed := strings.TrimSpace(value)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	return nil
+}
+
+// watchModel follows a model's training until it is complete, showing a
+// spinner on terminals and status changes otherwise. It reports whether
+// the model completed; failures have been printed already.
+func watchModel(client *mindsdb.MindsDBClient, project, name string, timeout time.Duration) bool {
+	if project == "" {
+		project = mindsdb.DefaultProject
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	spinner := newModelSpinner(project + "." + name)
+	state, err := client.WaitForModel(ctx, project, name, modelPollInterval, spinner.update)
+	spinner.stop()
+
+	var trainingErr *mindsdb.ModelTrainingError
+	switch {
+	case err == nil:
+		color.Green("✅ Model %s.%s is complete (version %s) after %s", project, name, state.Version, spinner.elapsed())
+		return true
+	case errors.As(err, &trainingErr):
+		color.Red("❌ %v", trainingErr)
+	case errors.Is(err, context.DeadlineExceeded):
+		color.Red("❌ Model %s.%s did not finish training within %s", project, name, timeout)
+	case errors.Is(err, context.Canceled):
+		color.Yellow("⏹️  Stopped watching %s.%s; training continues in MindsDB", project, name)
+	default:
+		color.Red("❌ Failed to get the status of %s.%s: %v", project, name, err)
+	}
+	return false
+}
+
+// modelSpinner shows the training status with the elapsed time. On a
+// terminal the line is redrawn with a spinner; otherwise a line is printed
+// whenever the status changes.
+type modelSpinner struct {
+	model   string
+	started time.Time
+	tty     bool
+
+	mu     sync.Mutex
+	status string
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newModelSpinner(model string) *modelSpinner {
+	s := &modelSpinner{
+		model:   model,
+		started: time.Now(),
+		tty:     term.IsTerminal(int(os.Stdout.Fd())),
+		done:    make(chan struct{}),
+	}
+	if s.tty {
+		s.wg.Add(1)
+		go s.spin()
+	}
+	return s
+}
+
+// update records the latest polled state
+func (s *modelSpinner) update(state *mindsdb.ModelState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.tty && state.Status != s.status {
+		fmt.Printf("⏳ %s: %s (%s)\n", s.model, state.Status, s.elapsed())
+	}
+	s.status = state.Status
+}
+
+func (s *modelSpinner) spin() {
+	defer s.wg.Done()
+	frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		s.mu.Lock()
+		status := s.status
+		s.mu.Unlock()
+		if status == "" {
+			status = "checking"
+		}
+		fmt.Printf("\r%s %s: %s (%s)\033[K", frames[frame%len(frames)], s.model, status, s.elapsed())
+
+		select {
+		case <-s.done:
+			fmt.Print("\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stop clears the spinner line
+func (s *modelSpinner) stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// elapsed returns the time since watching started, rounded to seconds
+func (s *modelSpinner) elapsed() time.Duration {
+	return time.Since(s.started).Round(time.Second)
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{modelsWatchCmd, modelsDescribeCmd} {
+		modelsConn.register(cmd)
+		cmd.Flags().StringVar(&modelsProject, "project", "", "Project of the model (default \""+mindsdb.DefaultProject+"\")")
+		modelsCmd.AddCommand(cmd)
+	}
+	modelsWatchCmd.Flags().DurationVar(&modelsWatchTimeout, "timeout", 0, "Give up after this long (default wait until training finishes)")
+	modelsDescribeCmd.Flags().StringVarP(&modelsDescribeOutput, "output", "o", "text", "Output format: text or json")
+}
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"mindsdb-go-cli/internal/mindsdb"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -14,14 +18,14 @@ import (
 )
 
 var querySQL string
-var queryHost, queryUser, queryPass string
-var queryEmbedded bool
+var queryConn connectionFlags
 var queryFormat string
 var queryMaxWidth int
 var queryCompact bool
 var queryVertical bool
 var queryLimit int
 var queryForceTable bool
+var queryTimeout time.Duration
 
 var queryCmd = &cobra.Command{
 	Use:   "query [SQL]",
@@ -37,13 +41,19 @@ Examples:
   mindsdb-cli query "SELECT * FROM models"
   mindsdb-cli query --sql "DESCRIBE information_schema.models"
   mindsdb-cli query --embedded "SELECT name FROM models"
-  mindsdb-cli query --host localhost:47335 --user admin --pass admin "SHOW TABLES"
+  mindsdb-cli query --host localhost:47335 --user admin "SHOW TABLES"     # Prompts for the password
+  mindsdb-cli query --protocol http --host localhost:47334 "SHOW DATABASES"
+  mindsdb-cli query --profile prod "SHOW DATABASES"                   # Use a saved profile
   mindsdb-cli query --format json "SELECT * FROM models"
   mindsdb-cli query --max-width 40 "SELECT * FROM training_data"
   mindsdb-cli query --compact "SELECT * FROM large_table"
   mindsdb-cli query --vertical "SELECT * FROM models"     # Force vertical layout
   mindsdb-cli query --limit 3 "SELECT * FROM big_table"   # Limit rows displayed
-  mindsdb-cli query --force-table "SHOW HANDLERS"         # Force table format even if wide`,
+  mindsdb-cli query --force-table "SHOW HANDLERS"         # Force table format even if wide
+  mindsdb-cli query --timeout 30s "SELECT * FROM my_model WHERE x = 1"
+
+Press Ctrl-C to cancel a running query. In interactive mode this only aborts
+the current statement and returns to the mindsdb> prompt.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get SQL query from args or flag
 		var sql string
@@ -52,8 +62,14 @@ Examples:
 		} else if querySQL != "" {
 			sql = querySQL
 		} else {
+			// The password would use up stdin, leaving nothing for the prompt
+			if queryConn.passwordStdin {
+				color.Red("❌ --password-stdin cannot be used in interactive mode")
+				fmt.Printf("   Pass the SQL as an argument or with --sql, or use %s or --password-file\n", envPassword)
+				return
+			}
 			// Start interactive mode
-			startInteractiveMode()
+			startInteractiveMode(cmd)
 			return
 		}
 
@@ -61,30 +77,54 @@ Examples:
 		fmt.Println()
 
 		// Connect to MindsDB
-		client, err := connectToMindsDB()
+		client, err := connectToMindsDB(cmd, &queryConn)
 		if err != nil {
-			color.Red("❌ Connection failed: %v", err)
 			return
 		}
 		defer client.Close()
 
 		// Execute single query
-		if err := executeAndDisplayQuery(client, sql); err != nil {
-			color.Red("❌ Query execution failed: %v", err)
+		ctx, stop := statementContext()
+		defer stop()
+		if err := executeAndDisplayQuery(ctx, client, sql); err != nil {
+			color.Red("❌ Query execution failed: %v", describeQueryError(ctx, err))
 			return
 		}
 	},
 }
 
-func executeAndDisplayQuery(client *mindsdb.MindsDBClient, sql string) error {
-	rows, err := client.Query(sql)
-	if err != nil {
-		return err
+// statementContext returns the context for a single statement. It expires
+// after --timeout and is cancelled by Ctrl-C; while it is active SIGINT no
+// longer terminates the process. stop must be called once the statement is
+// done to restore the default signal handling.
+func statementContext() (context.Context, context.CancelFunc) {
+	ctx := context.Background()
+	cancelTimeout := context.CancelFunc(func() {})
+	if queryTimeout > 0 {
+		ctx, cancelTimeout = context.WithTimeout(ctx, queryTimeout)
 	}
-	defer rows.Close()
 
-	// Get column names
-	columns, err := rows.Columns()
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt)
+	return ctx, func() {
+		stopSignals()
+		cancelTimeout()
+	}
+}
+
+// describeQueryError replaces driver errors caused by an expired or
+// cancelled context with a clearer message
+func describeQueryError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf("query timed out after %s", queryTimeout)
+	case context.Canceled:
+		return fmt.Errorf("query cancelled")
+	}
+	return err
+}
+
+func executeAndDisplayQuery(ctx context.Context, client *mindsdb.MindsDBClient, sql string) error {
+	columns, rows, err := collectRows(ctx, client, sql)
 	if err != nil {
 		return err
 	}
@@ -93,6 +133,23 @@ func executeAndDisplayQuery(client *mindsdb.MindsDBClient, sql string) error {
 		color.Green("✅ Query executed successfully (no results returned)")
 		return nil
 	}
+	return renderRows(queryFormat, columns, rows)
+}
+
+// collectRows runs a query and returns its columns and all rows, with
+// every value converted to a string and NULLs shown as "NULL"
+func collectRows(ctx context.Context, client *mindsdb.MindsDBClient, sql string) ([]string, [][]string, error) {
+	rows, err := client.Query(ctx, sql)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	// Get column names
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Collect all data first
 	var allRows [][]string
@@ -104,7 +161,7 @@ func executeAndDisplayQuery(client *mindsdb.MindsDBClient, sql string) error {
 		}
 
 		if err := rows.Scan(valuePtrs...); err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		row := make([]string, len(columns))
@@ -127,27 +184,37 @@ func executeAndDisplayQuery(client *mindsdb.MindsDBClient, sql string) error {
 		}
 		allRows = append(allRows, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return columns, allRows, nil
+}
 
-	// Display results based on format
-	switch queryFormat {
+// renderRows displays a result set in the given format: table, json or csv
+func renderRows(format string, columns []string, rows [][]string) error {
+	switch format {
 	case "json":
-		return displayAsJSON(columns, allRows)
+		return displayAsJSON(columns, rows)
 	case "csv":
-		return displayAsCSV(columns, allRows)
+		return displayAsCSV(columns, rows)
 	default:
-		return displayAsTable(columns, allRows)
+		return displayAsTable(columns, rows)
 	}
 }
 
+// displayAsJSON prints the rows as a JSON array of objects. The heading and
+// summary go to stderr so the output can be piped.
 func displayAsJSON(columns []string, rows [][]string) error {
-	color.New(color.FgHiMagenta, color.Bold).Println("📊 Results (JSON):")
-	fmt.Println()
+	color.New(color.FgHiMagenta, color.Bold).Fprintln(os.Stderr, "📊 Results (JSON):")
+	fmt.Fprintln(os.Stderr)
 
 	fmt.Println("[")
 	for i, row := range rows {
 		fmt.Print("  {")
 		for j, col := range columns {
-			fmt.Printf(`"%s": "%s"`, col, strings.ReplaceAll(row[j], `"`, `\"`))
+			key, _ := json.Marshal(col)
+			value, _ := json.Marshal(row[j])
+			fmt.Printf(`%s: %s`, key, value)
 			if j < len(columns)-1 {
 				fmt.Print(", ")
 			}
@@ -160,13 +227,15 @@ func displayAsJSON(columns []string, rows [][]string) error {
 	}
 	fmt.Println("]")
 
-	color.Green("✅ Query completed successfully (%d rows)", len(rows))
+	fmt.Fprintln(os.Stderr, color.GreenString("✅ Query completed successfully (%d rows)", len(rows)))
 	return nil
 }
 
+// displayAsCSV prints the rows as CSV with a header line. The heading and
+// summary go to stderr so the output can be redirected to a file.
 func displayAsCSV(columns []string, rows [][]string) error {
-	color.New(color.FgHiMagenta, color.Bold).Println("📊 Results (CSV):")
-	fmt.Println()
+	color.New(color.FgHiMagenta, color.Bold).Fprintln(os.Stderr, "📊 Results (CSV):")
+	fmt.Fprintln(os.Stderr)
 
 	// Header
 	for i, col := range columns {
@@ -188,7 +257,7 @@ func displayAsCSV(columns []string, rows [][]string) error {
 		fmt.Println()
 	}
 
-	color.Green("✅ Query completed successfully (%d rows)", len(rows))
+	fmt.Fprintln(os.Stderr, color.GreenString("✅ Query completed successfully (%d rows)", len(rows)))
 	return nil
 }
 
@@ -638,55 +707,19 @@ func printTableBorder(colWidths []int, left, middle, right, fill string) {
 	fmt.Println(right)
 }
 
-func connectToMindsDB() (*mindsdb.MindsDBClient, error) {
-	var client *mindsdb.MindsDBClient
-	var err error
-
-	if queryEmbedded {
-		color.Blue("🔗 Connecting to embedded MindsDB...")
-		client, err = mindsdb.NewEmbeddedClient(queryUser, queryPass)
-		if err != nil {
-			color.Red("❌ Failed to connect to embedded MindsDB: %v", err)
-			color.Yellow("💡 Try 'mindsdb-cli start' first to ensure the container is running.")
-			return nil, err
-		}
-	} else if queryHost != "" {
-		color.Blue("🔗 Connecting to MindsDB at %s...", queryHost)
-		if queryUser == "" || queryPass == "" {
-			color.Red("❌ Username and password are required for external connections.")
-			fmt.Println("   Use: mindsdb-cli query --host <host> --user <user> --pass <pass>")
-			return nil, fmt.Errorf("missing credentials")
-		}
-		client, err = mindsdb.NewClient(queryHost, queryUser, queryPass)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		// Default to embedded mode
-		color.Blue("🔗 Connecting to embedded MindsDB (default)...")
-		client, err = mindsdb.NewEmbeddedClient("", "")
-		if err != nil {
-			color.Red("❌ Failed to connect to embedded MindsDB: %v", err)
-			color.Yellow("💡 Try 'mindsdb-cli start' first or use --host for external connections.")
-			return nil, err
-		}
-	}
-
-	return client, nil
-}
-
-func startInteractiveMode() {
+func startInteractiveMode(cmd *cobra.Command) {
 	// Print welcome message
 	color.New(color.FgHiCyan, color.Bold).Println("🧠 MindsDB Interactive SQL Mode")
 	fmt.Println("================================")
 	fmt.Println()
 	color.Yellow("💡 Type SQL queries and press Enter to execute")
 	color.Yellow("💡 Use semicolon (;) for multi-line queries")
-	color.Yellow("💡 Commands: .help, .exit, .format, .compact, .vertical, .limit")
+	color.Yellow("💡 Commands: .help, .exit, .format, .compact, .vertical, .limit, .timeout")
+	color.Yellow("💡 Press Ctrl-C to cancel a running query")
 	fmt.Println()
 
 	// Connect to MindsDB
-	client, err := connectToMindsDB()
+	client, err := connectToMindsDB(cmd, &queryConn)
 	if err != nil {
 		return
 	}
@@ -777,6 +810,7 @@ func handleSpecialCommand(command string) bool {
 		color.White("  .compact                 Toggle compact table mode")
 		color.White("  .vertical                Toggle vertical layout for wide tables")
 		color.White("  .limit <number>          Set row limit (0 for no limit)")
+		color.White("  .timeout <duration>      Set query timeout (e.g. 30s, 0 for no limit)")
 		color.White("  .clear                   Clear screen")
 		fmt.Println()
 		color.Yellow("💡 SQL Tips:")
@@ -828,6 +862,19 @@ func handleSpecialCommand(command string) bool {
 			}
 		}
 
+	case strings.HasPrefix(command, ".timeout "):
+		timeoutStr := strings.TrimSpace(strings.TrimPrefix(command, ".timeout "))
+		if newTimeout, err := time.ParseDuration(timeoutStr); err == nil && newTimeout >= 0 {
+			queryTimeout = newTimeout
+			if queryTimeout == 0 {
+				color.Green("✅ Query timeout disabled")
+			} else {
+				color.Green("✅ Query timeout set to: %s", queryTimeout)
+			}
+		} else {
+			color.Red("❌ Invalid duration. Use: .timeout 30s or .timeout 0 for no limit")
+		}
+
 	case command == ".clear":
 		// Clear screen
 		fmt.Print("\033[2J\033[H")
@@ -845,22 +892,24 @@ func executeInteractiveQuery(client *mindsdb.MindsDBClient, sql string) {
 	color.Cyan("🔍 Executing: %s", sql)
 	fmt.Println()
 
-	if err := executeAndDisplayQuery(client, sql); err != nil {
-		color.Red("❌ Error: %v", err)
+	// Ctrl-C only cancels this statement, the session stays open
+	ctx, stop := statementContext()
+	defer stop()
+
+	if err := executeAndDisplayQuery(ctx, client, sql); err != nil {
+		color.Red("❌ Error: %v", describeQueryError(ctx, err))
 	}
 	fmt.Println()
 }
 
 func init() {
 	queryCmd.Flags().StringVar(&querySQL, "sql", "", "SQL query to execute")
-	queryCmd.Flags().StringVar(&queryHost, "host", "", "MindsDB host (e.g., localhost:47335)")
-	queryCmd.Flags().StringVar(&queryUser, "user", "", "MindsDB username")
-	queryCmd.Flags().StringVar(&queryPass, "pass", "", "MindsDB password")
-	queryCmd.Flags().BoolVar(&queryEmbedded, "embedded", false, "Use embedded MindsDB instance")
+	queryConn.register(queryCmd)
 	queryCmd.Flags().StringVar(&queryFormat, "format", "table", "Output format: table, json, csv")
 	queryCmd.Flags().IntVar(&queryMaxWidth, "max-width", 20, "Maximum column width for table display")
 	queryCmd.Flags().BoolVar(&queryCompact, "compact", false, "Use compact mode for table display")
 	queryCmd.Flags().BoolVar(&queryVertical, "vertical", false, "Force vertical layout for wide tables")
 	queryCmd.Flags().IntVar(&queryLimit, "limit", 0, "Limit the number of rows displayed")
 	queryCmd.Flags().BoolVar(&queryForceTable, "force-table", false, "Force traditional table format even for wide tables")
+	queryCmd.Flags().DurationVar(&queryTimeout, "timeout", 0, "Cancel queries that run longer than this (e.g. 30s, 5m; 0 for no limit)")
 }
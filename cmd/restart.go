@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var restartInstance string
+var restartTimeout time.Duration
+var restartWaitTimeout time.Duration
+
+var restartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart embedded MindsDB instance",
+	Long: `Restart the embedded MindsDB container and wait until it accepts SQL
+connections again.
+
+The container keeps its image, ports, options and data; use 'start' with
+new options to recreate it instead. Like 'stop', MindsDB gets 10 seconds to
+shut down unless --timeout is given, and a warning is shown when models are
+still training or generating. A stopped container is simply started.
+
+Examples:
+  mindsdb-cli restart
+  mindsdb-cli restart --instance scratch
+  mindsdb-cli restart --timeout 2m --wait-timeout 10m`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mindsdb.ValidateInstanceName(restartInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		fmt.Printf("🔄 Restarting embedded MindsDB instance%s...\n", instanceSuffix(restartInstance))
+
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+		client := &mindsdb.MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: restartInstance}
+
+		isRunning, _, err := client.GetContainerStatus()
+		if err != nil {
+			fmt.Printf("❌ Failed to get container status: %v\n", err)
+			return
+		}
+		if isRunning {
+			warnBusyModels(client, "restarting")
+		}
+
+		opts := mindsdb.EmbeddedOptions{WaitTimeout: restartWaitTimeout, Instance: restartInstance}
+		if err := client.RestartEmbeddedMindsDB(opts, stopTimeoutFlag(cmd, restartTimeout)); err != nil {
+			fmt.Printf("❌ Failed to restart embedded MindsDB: %v\n", err)
+			return
+		}
+		fmt.Println("✅ Embedded MindsDB restarted successfully!")
+	},
+}
+
+func init() {
+	registerInstanceFlag(restartCmd, &restartInstance)
+	restartCmd.Flags().DurationVar(&restartTimeout, "timeout", 10*time.Second, "How long MindsDB may take to shut down before it is killed")
+	restartCmd.Flags().DurationVar(&restartWaitTimeout, "wait-timeout", mindsdb.DefaultWaitTimeout, "How long to wait for MindsDB to accept SQL connections")
+}
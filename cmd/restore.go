@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var restoreInstance string
+var restoreForce bool
+var restoreWaitTimeout time.Duration
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore an embedded MindsDB instance from a backup",
+	Long: `Restore the data of an embedded instance from a tarball created by
+'mindsdb-cli backup'.
+
+The existing container and its data volume are removed, a fresh container is
+created with the backup copied into its storage directory, and the instance
+is started. The instance keeps its current host ports.
+
+Everything currently stored in the instance is replaced, so you are asked for
+confirmation unless --force is given. This includes a data volume kept after
+'stop --remove', even though the instance has no container.
+
+Examples:
+  mindsdb-cli restore ./before-upgrade.tar.gz
+  mindsdb-cli restore mindsdb-default-20240101-120000.tar.gz --instance scratch --force`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		file := args[0]
+		if err := mindsdb.ValidateInstanceName(restoreInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if err := mindsdb.ValidateBackup(file); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+
+		client := &mindsdb.MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: restoreInstance}
+		container, err := client.Container()
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		hasData, err := client.DataVolumeExists(context.Background())
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if (container != nil || hasData) && !restoreForce {
+			target := "data volume " + mindsdb.InstanceVolumeName(restoreInstance)
+			if container != nil {
+				target = container.Name
+			}
+			fmt.Printf("⚠️  Restoring replaces all data of %s: models, databases, ML engines, jobs,\n", target)
+			fmt.Println("   knowledge bases and uploaded files. Consider 'mindsdb-cli backup' first.")
+			if !confirm("Replace the instance's data with " + file + "?") {
+				fmt.Println("ℹ️  Restore cancelled")
+				return
+			}
+		}
+
+		fmt.Printf("♻️  Restoring %s from %s...\n", mindsdb.InstanceContainerName(restoreInstance), file)
+		_, err = client.RestoreEmbeddedMindsDB(context.Background(), file, mindsdb.EmbeddedOptions{
+			WaitTimeout: restoreWaitTimeout,
+			Instance:    restoreInstance,
+		})
+		if err != nil {
+			fmt.Printf("❌ Restore failed: %v\n", err)
+			return
+		}
+		fmt.Println("✅ Restore complete")
+	},
+}
+
+func init() {
+	registerInstanceFlag(restoreCmd, &restoreInstance)
+	restoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Replace existing data without asking")
+	restoreCmd.Flags().DurationVar(&restoreWaitTimeout, "wait-timeout", mindsdb.DefaultWaitTimeout, "How long to wait for MindsDB to accept SQL connections")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
 
 	"github.com/spf13/cobra"
 )
@@ -25,10 +26,23 @@ func init() {
 	rootCmd.AddCommand(connectCmd)
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(restartCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(waitCmd)
+	rootCmd.AddCommand(instancesCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(imageCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
 	rootCmd.AddCommand(listModelsCmd)
 	rootCmd.AddCommand(createModelCmd)
+	rootCmd.AddCommand(modelsCmd)
 	rootCmd.AddCommand(queryCmd)
+	rootCmd.AddCommand(configCmd)
+
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Connection profile to use (see 'mindsdb-cli config')")
+	rootCmd.PersistentFlags().StringVar(&runtimeName, "runtime", mindsdb.RuntimeAuto, "Container runtime for embedded MindsDB: auto, docker, podman or nerdctl (env "+mindsdb.EnvRuntime+")")
 }
 
 func printBanner() {
@@ -44,30 +58,41 @@ func printBanner() {
 	fmt.Printf("🧠  MindsDB CLI v%s\n", version)
 	fmt.Println("-----------------------")
 	fmt.Println("\nWelcome to the MindsDB Command Line Interface!")
-	fmt.Println("Interact with your AI models directly from your terminal.\n")
+	fmt.Println("Interact with your AI models directly from your terminal.")
+	fmt.Println()
 
 	fmt.Println("📦 Embedded MindsDB Commands:")
-	fmt.Println("  start          Start embedded MindsDB instance (Docker)")
+	fmt.Println("  start          Start embedded MindsDB instance (Docker/Podman)")
 	fmt.Println("  stop           Stop embedded MindsDB instance")
+	fmt.Println("  restart        Restart embedded MindsDB and wait until it is ready")
 	fmt.Println("  status         Check MindsDB instance status")
+	fmt.Println("  wait           Wait until MindsDB accepts SQL (for CI)")
+	fmt.Println("  instances      List embedded instances (--instance <name> selects one)")
+	fmt.Println("  logs           Show embedded MindsDB logs (--follow, --level, --grep)")
+	fmt.Println("  upgrade        Upgrade (or roll back) the embedded MindsDB version")
+	fmt.Println("  image          Save the MindsDB image for offline machines")
+	fmt.Println("  backup         Back up embedded MindsDB data to a tarball")
+	fmt.Println("  restore        Restore embedded MindsDB data from a backup")
 	fmt.Println("")
 	fmt.Println("🔗 Connection Commands:")
 	fmt.Println("  connect        Connect to a MindsDB instance")
+	fmt.Println("  config         Manage saved connection profiles")
 	fmt.Println("")
 	fmt.Println("🤖 Model Management:")
 	fmt.Println("  list-models    List available ML models")
-	fmt.Println("  create-model   Create and train a new ML model")
+	fmt.Println("  create-model   Create and train a new ML model (--wait to follow training)")
+	fmt.Println("  models         Follow training or describe a model (models watch|describe <name>)")
 	fmt.Println("  query          Execute SQL queries and predictions")
 	fmt.Println("")
 	fmt.Println("💡 Quick Start:")
 	fmt.Println("  # Start embedded MindsDB (no separate installation needed!)")
-	fmt.Println("  mindsdb-cli start --user admin --pass admin")
+	fmt.Println("  mindsdb-cli start")
 	fmt.Println("")
 	fmt.Println("  # Connect to embedded instance")
-	fmt.Println("  mindsdb-cli connect --embedded --user admin --pass admin")
+	fmt.Println("  mindsdb-cli connect --embedded")
 	fmt.Println("")
 	fmt.Println("  # Or connect to external MindsDB")
-	fmt.Println("  mindsdb-cli connect --host localhost:47335 --user admin --pass admin")
+	fmt.Println("  mindsdb-cli connect --host localhost:47335 --user admin   # prompts for the password")
 	fmt.Println("")
 	fmt.Println("Use 'mindsdb-cli <command> --help' for more information about a command.")
 }
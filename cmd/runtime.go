@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+)
+
+// runtimeName is the global --runtime flag
+var runtimeName string
+
+// detectRuntime returns the container runtime selected by --runtime, or
+// prints why none is usable and returns nil
+func detectRuntime() mindsdb.Runtime {
+	rt, err := mindsdb.DetectRuntime(runtimeName)
+	if err != nil {
+		fmt.Printf("❌ Container runtime not available: %v\n", err)
+		fmt.Println("   Please install Docker, Podman or nerdctl, or ensure its daemon is running.")
+		return nil
+	}
+	return rt
+}
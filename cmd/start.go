@@ -1,68 +1,205 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"mindsdb-go-cli/internal/mindsdb"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
-var startUser, startPass string
+var startCreds credentialFlags
+var startWaitTimeout time.Duration
+var startHTTPPort, startMySQLPort string
+var startAutoPort bool
+var startInstance string
+var startVersion string
+var startImageArchive string
+var startPull bool
+var startCPUs, startMemory, startNetwork string
+var startEnv, startMounts []string
+var startRecreate bool
 
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start embedded MindsDB instance",
-	Long: `Start an embedded MindsDB instance using Docker.
+	Long: `Start an embedded MindsDB instance in a container.
     
 This command will:
-1. Check if Docker is available
-2. Pull the MindsDB Docker image if needed
+1. Find a container runtime (Docker, Podman or nerdctl; see --runtime)
+2. Pull the MindsDB image if needed
 3. Start the MindsDB container
-4. Wait for MindsDB to be ready
+4. Wait for MindsDB to be ready (container up, port open, SQL ping)
 
-MindsDB by default doesn't require authentication unless specifically configured.
+The Web UI/HTTP API and the MySQL protocol are published on host ports 47334
+and 47335 unless --http-port or --mysql-port is given. The ports are checked
+before the container is created; with --auto-port, free ports are picked
+instead of failing. Ports are fixed when the container is created, and other
+commands read them from the container.
+
+The image is mindsdb/mindsdb:latest unless --version selects another tag.
+It is only pulled when it is not present locally (or with --pull). On
+machines without registry access, load it from a tarball created with
+'mindsdb-cli image save' using --image-archive.
+The digest the tag resolved to is recorded on the container and shown by
+'mindsdb-cli status', so everyone can check they run the same MindsDB. Use
+'mindsdb-cli upgrade' to move an existing instance to another version.
+
+Use --instance to run several independent instances side by side. Each gets
+its own container, data volume and, unless ports are given, free host ports.
+
+--cpus, --memory, --env, --network and --mount are applied when the container
+is created and recorded on it. When they are given for an existing container
+that was created with different values, the differences are shown and the
+container can be recreated with the new ones (asked interactively, or with
+--recreate). Recreating keeps the image, ports and data volume. Environment
+values are never shown, only the variable names.
+
+Without --user, MindsDB runs with authentication disabled (its default).
+With --user and a password, the container is created with authentication
+enabled for the Web UI, HTTP API and SQL: a MindsDB config file is generated
+in ~/.config/mindsdb-cli/instances/<instance>/ and mounted into the
+container. That file is also where other commands ('connect --embedded',
+'wait', ...) get the credentials from, so they do not need to be repeated.
+Changing the credentials of an existing container recreates it like the
+options above. 'stop --purge' deletes the file together with the data.
+
+Passwords can be given with MINDSDB_PASSWORD, --password-file,
+--password-stdin or a prompt and are never printed.
 
 Examples:
-  mindsdb-cli start                              # No authentication (MindsDB default)
-  mindsdb-cli start --user admin --pass mypass  # Use custom credentials if auth is enabled`,
+  mindsdb-cli start                                            # No authentication (MindsDB default)
+  MINDSDB_PASSWORD=mypass mindsdb-cli start --user admin      # Enable authentication
+  mindsdb-cli start --user admin --password-file ./mindsdb.pass
+  mindsdb-cli start --wait-timeout 10m                         # Slow machine or first image pull
+  mindsdb-cli start --runtime podman                           # Rootless Podman instead of Docker
+  mindsdb-cli start --http-port 8080 --mysql-port 3307
+  mindsdb-cli start --auto-port                                # Pick free ports if the defaults are taken
+  mindsdb-cli start --instance scratch                         # A second, throwaway instance
+  mindsdb-cli start --version v25.1.2                          # Pin the MindsDB version
+  mindsdb-cli start --image-archive ./mindsdb-v25.1.2.tar      # Offline: load the image from disk
+  mindsdb-cli start --cpus 2 --memory 4g                       # Limit resources
+  mindsdb-cli start --env OPENAI_API_KEY --network mynet       # Pass a host variable, join a network
+  mindsdb-cli start --mount ./models:/models:ro                # Make local files available to MindsDB`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("🚀 Starting embedded MindsDB instance...")
+		if err := mindsdb.ValidateInstanceName(startInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if err := mindsdb.ValidateVersion(startVersion); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if startImageArchive != "" && startPull {
+			fmt.Println("❌ --image-archive and --pull cannot be used together")
+			return
+		}
+		containerOpts, err := mindsdb.ParseContainerOptions(startCPUs, startMemory, startEnv, startNetwork, startMounts)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		fmt.Printf("🚀 Starting embedded MindsDB instance%s...\n", instanceSuffix(startInstance))
+
+		// Check that a container runtime is available
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+		fmt.Printf("📦 Using container runtime: %s\n", rt.Name())
 
-		// Check if Docker is available
-		if !mindsdb.IsDockerAvailable() {
-			fmt.Println("❌ Docker is not available.")
-			fmt.Println("   Please install Docker Desktop or ensure Docker daemon is running.")
+		startPass, hasPass, err := startCreds.password(cmd, "")
+		if err == nil && startCreds.user != "" && !hasPass {
+			startPass, err = promptPassword(startCreds.user)
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
 			return
 		}
 
-		// MindsDB by default doesn't require authentication unless configured
-		// Only show credentials if they are provided
-		if startUser != "" || startPass != "" {
-			fmt.Printf("📋 Using credentials: %s / %s\n", startUser, startPass)
-		} else {
+		// Authentication is only enabled when credentials are given; never
+		// show the password itself
+		auth := startCreds.user != ""
+		switch {
+		case !auth && hasPass:
+			fmt.Println("ℹ️  Ignoring the password: authentication is only enabled together with --user")
+			fmt.Println("📋 Using MindsDB default (no authentication required)")
+		case auth && startPass == "":
+			fmt.Println("❌ Authentication needs a non-empty password")
+			return
+		case auth:
+			fmt.Printf("📋 Enabling authentication: %s / %s\n", startCreds.user, redact(startPass))
+		default:
 			fmt.Println("📋 Using MindsDB default (no authentication required)")
 		}
 
 		// Create embedded client (this will start the container)
-		client, err := mindsdb.NewEmbeddedClient(startUser, startPass)
+		opts := mindsdb.EmbeddedOptions{
+			User:         startCreds.user,
+			Password:     startPass,
+			WaitTimeout:  startWaitTimeout,
+			Runtime:      rt.Name(),
+			Ports:        mindsdb.Ports{HTTP: startHTTPPort, MySQL: startMySQLPort},
+			AutoPort:     startAutoPort,
+			Instance:     startInstance,
+			Version:      startVersion,
+			ImageArchive: startImageArchive,
+			Pull:         startPull,
+			Container:    containerOpts,
+			Recreate:     startRecreate,
+			Auth:         auth,
+		}
+		client, err := mindsdb.NewEmbeddedClient(opts)
+
+		var driftErr *mindsdb.ConfigDriftError
+		if errors.As(err, &driftErr) {
+			fmt.Printf("⚠️  Container %s was created with different settings:\n", driftErr.Container)
+			for _, change := range driftErr.Changes {
+				fmt.Printf("   - %s\n", change)
+			}
+			if !confirmFlag("Recreate the container with the new settings? The image, ports and data are kept.", "--recreate") {
+				fmt.Println("❌ Not started; run without these flags to start the container as it is, or pass --recreate")
+				return
+			}
+			opts.Recreate = true
+			client, err = mindsdb.NewEmbeddedClient(opts)
+		}
 		if err != nil {
 			fmt.Printf("❌ Failed to start embedded MindsDB: %v\n", err)
+			var portErr *mindsdb.PortInUseError
+			if errors.As(err, &portErr) {
+				fmt.Println("   Choose other ports with --http-port/--mysql-port, or use --auto-port.")
+			}
 			return
 		}
 		defer client.Close()
 
 		fmt.Println("✅ Embedded MindsDB started successfully!")
-		fmt.Println("   - Web UI: http://localhost:47334")
-		fmt.Println("   - Database: localhost:47335")
-		fmt.Println("   - Container: mindsdb-cli-embedded")
+		fmt.Printf("   - Web UI: http://localhost:%s\n", client.Ports.HTTP)
+		fmt.Printf("   - Database: localhost:%s\n", client.Ports.MySQL)
+		fmt.Printf("   - Container: %s\n", mindsdb.InstanceContainerName(startInstance))
 		fmt.Println()
-		fmt.Println("💡 Use 'mindsdb-cli status' to check the status")
-		fmt.Println("💡 Use 'mindsdb-cli stop' to stop the instance")
-		fmt.Println("💡 Use 'mindsdb-cli connect --embedded' to connect and run queries")
+		fmt.Printf("💡 Use 'mindsdb-cli status%s' to check the status\n", mindsdb.InstanceArg(startInstance))
+		fmt.Printf("💡 Use 'mindsdb-cli stop%s' to stop the instance\n", mindsdb.InstanceArg(startInstance))
+		fmt.Printf("💡 Use 'mindsdb-cli connect --embedded%s' to connect and run queries\n", mindsdb.InstanceArg(startInstance))
 	},
 }
 
 func init() {
-	startCmd.Flags().StringVarP(&startUser, "user", "u", "", "MindsDB username (optional, only needed if auth is enabled)")
-	startCmd.Flags().StringVarP(&startPass, "pass", "p", "", "MindsDB password (optional, only needed if auth is enabled)")
+	startCreds.register(startCmd, " (optional, enables authentication)")
+	registerInstanceFlag(startCmd, &startInstance)
+	startCmd.Flags().StringVar(&startVersion, "version", "", "MindsDB image tag for a new container (default \""+mindsdb.DefaultVersion+"\")")
+	startCmd.Flags().StringVar(&startImageArchive, "image-archive", "", "Load the MindsDB image from a tar archive instead of pulling it")
+	startCmd.Flags().BoolVar(&startPull, "pull", false, "Pull the image even if it is present locally")
+	startCmd.Flags().StringVar(&startHTTPPort, "http-port", "", "Host port for the Web UI and HTTP API (default "+mindsdb.MindsDBPort+")")
+	startCmd.Flags().StringVar(&startMySQLPort, "mysql-port", "", "Host port for the MySQL protocol (default "+mindsdb.MySQLPort+")")
+	startCmd.Flags().BoolVar(&startAutoPort, "auto-port", false, "Pick free host ports if the requested ones are in use")
+	startCmd.Flags().StringVar(&startCPUs, "cpus", "", "Number of CPUs the container may use, e.g. 1.5")
+	startCmd.Flags().StringVar(&startMemory, "memory", "", "Memory limit for the container, e.g. 512m or 4g")
+	startCmd.Flags().StringArrayVar(&startEnv, "env", nil, "Set an environment variable in the container, KEY=VALUE or KEY to copy it from the host (repeatable)")
+	startCmd.Flags().StringVar(&startNetwork, "network", "", "Container network to attach to")
+	startCmd.Flags().StringArrayVar(&startMounts, "mount", nil, "Mount a host path or volume, source:/container/path[:ro] (repeatable)")
+	startCmd.Flags().BoolVar(&startRecreate, "recreate", false, "Recreate an existing container with the given options")
+	startCmd.Flags().DurationVar(&startWaitTimeout, "wait-timeout", mindsdb.DefaultWaitTimeout, "How long to wait for MindsDB to accept SQL connections")
 }
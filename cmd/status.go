@@ -1,84 +1,232 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"mindsdb-go-cli/internal/mindsdb"
-	"os/exec"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
+var statusInstance string
+var statusOutput string
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check MindsDB instance status",
 	Long: `Check the status of your embedded MindsDB instance.
-    
+
 This command shows:
-- Whether Docker is available
-- MindsDB container status
+- Which container runtime is used (Docker, Podman or nerdctl)
+- MindsDB container status, uptime and health check
+- CPU and memory usage of the container
+- Whether the HTTP API and the MySQL API answer, and the server version
+  reported by a SQL probe
 - Connection information if running
 
-Example:
-  mindsdb-cli status`,
+With --output json or yaml the same information is printed in a
+machine-readable form for monitoring scripts, and the command exits with a
+non-zero status unless the container is running and both APIs answer.
+Fields that do not apply (e.g. stats of a stopped container) are omitted;
+checks that could not be run are listed under "errors".
+
+Examples:
+  mindsdb-cli status
+  mindsdb-cli status --instance scratch
+  mindsdb-cli status --output json | jq .mysql.reachable`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("📊 MindsDB Status Check")
-		fmt.Println("======================")
-
-		// Check Docker availability
-		fmt.Print("🐳 Docker: ")
-		if !mindsdb.IsDockerAvailable() {
-			fmt.Println("❌ Not available")
-			fmt.Println("   Please install Docker Desktop or ensure Docker daemon is running.")
-			fmt.Println("   You can still connect to external MindsDB instances using:")
-			fmt.Println("   mindsdb-cli connect --host <host> --user <user> --pass <pass>")
+		if err := mindsdb.ValidateInstanceName(statusInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
 			return
 		}
-		fmt.Println("✅ Available")
 
-		// Get Docker version info
-		if cmd := exec.Command("docker", "version", "--format", "{{.Server.Version}}"); cmd != nil {
-			if output, err := cmd.Output(); err == nil {
-				fmt.Printf("   Version: %s", string(output))
-			}
+		switch statusOutput {
+		case "text":
+			printStatusText()
+		case "json", "yaml":
+			printStatusStructured()
+		default:
+			fmt.Printf("❌ Invalid output format %q (use text, json or yaml)\n", statusOutput)
 		}
+	},
+}
 
-		// Check MindsDB container status
-		fmt.Print("\n🧠 MindsDB Container: ")
+// collectStatus detects the runtime and gathers the instance's status
+func collectStatus() (*mindsdb.InstanceStatus, error) {
+	rt, err := mindsdb.DetectRuntime(runtimeName)
+	if err != nil {
+		return nil, err
+	}
+	client := &mindsdb.MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: statusInstance}
 
-		mindsdbClient := &mindsdb.MindsDBClient{
-			EmbeddedMode: true,
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return client.InstanceStatus(ctx)
+}
 
-		isRunning, startedAt, err := mindsdbClient.GetContainerStatus()
-		if err != nil {
-			fmt.Printf("❌ Error checking status: %v\n", err)
-			return
+// printStatusStructured prints the status as JSON or YAML and exits
+// non-zero unless the instance is healthy
+func printStatusStructured() {
+	status, err := collectStatus()
+	if err != nil {
+		// Still print a document, so scripts always get something to parse
+		status = &mindsdb.InstanceStatus{
+			Instance:  statusInstance,
+			Container: mindsdb.InstanceContainerName(statusInstance),
+			State:     "unknown",
+			Errors:    []string{err.Error()},
+		}
+		if status.Instance == "" {
+			status.Instance = mindsdb.DefaultInstance
 		}
+	}
+
+	var data []byte
+	if statusOutput == "json" {
+		data, err = json.MarshalIndent(status, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(status)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(data)
+
+	if !status.Healthy() {
+		os.Exit(1)
+	}
+}
 
-		if startedAt == "" {
-			fmt.Println("⚪ Not created")
-			fmt.Println("   Use 'mindsdb-cli start' to create and start a container")
-		} else if isRunning {
-			fmt.Println("✅ Running")
-			fmt.Println("   - Web UI: http://localhost:47334")
-			fmt.Println("   - Database: localhost:47335")
-			fmt.Println("   - Container: mindsdb-cli-embedded")
-			fmt.Printf("   - Started: %s\n", startedAt)
-		} else {
-			fmt.Println("🛑 Stopped")
-			fmt.Println("   Use 'mindsdb-cli start' to start the container")
+// printStatusText prints the status for humans
+func printStatusText() {
+	fmt.Println("📊 MindsDB Status Check")
+	fmt.Println("======================")
+
+	// Check container runtime availability
+	fmt.Print("🐳 Container runtime: ")
+	rt, err := mindsdb.DetectRuntime(runtimeName)
+	if err != nil {
+		fmt.Printf("❌ Not available: %v\n", err)
+		fmt.Println("   Please install Docker, Podman or nerdctl, or ensure its daemon is running.")
+		fmt.Println("   You can still connect to external MindsDB instances using:")
+		fmt.Println("   mindsdb-cli connect --host <host> --user <user>")
+		return
+	}
+	fmt.Printf("✅ %s\n", rt.Name())
+
+	// Check MindsDB container status
+	mindsdbClient := &mindsdb.MindsDBClient{
+		EmbeddedMode: true,
+		Runtime:      rt,
+		Instance:     statusInstance,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	status, err := mindsdbClient.InstanceStatus(ctx)
+	if err != nil {
+		fmt.Printf("\n🧠 MindsDB Container%s: ❌ Error checking status: %v\n", instanceSuffix(statusInstance), err)
+		return
+	}
+	if status.RuntimeVersion != "" {
+		fmt.Printf("   Version: %s\n", status.RuntimeVersion)
+	}
+
+	fmt.Printf("\n🧠 MindsDB Container%s: ", instanceSuffix(statusInstance))
+	switch {
+	case status.State == mindsdb.StateNotCreated:
+		fmt.Println("⚪ Not created")
+		fmt.Printf("   Use 'mindsdb-cli start%s' to create and start a container\n", mindsdb.InstanceArg(statusInstance))
+	case status.Running:
+		fmt.Println("✅ Running")
+		fmt.Printf("   - Web UI: http://localhost:%s\n", status.Ports.HTTP)
+		fmt.Printf("   - Database: localhost:%s\n", status.Ports.MySQL)
+		fmt.Printf("   - Container: %s\n", status.Container)
+		fmt.Printf("   - Started: %s (up %s)\n", status.StartedAt, time.Duration(status.UptimeSeconds)*time.Second)
+		fmt.Printf("   - Health check: %s\n", status.Health)
+		if status.Stats != nil {
+			fmt.Printf("   - CPU: %.1f%%, memory: %s / %s\n", status.Stats.CPUPercent,
+				formatBytes(status.Stats.MemoryUsage), formatBytes(status.Stats.MemoryLimit))
 		}
+		printContainerImage(status)
+		printAPIStatus("HTTP API", status.HTTP, "")
+		printAPIStatus("MySQL API", status.MySQL, status.ServerVersion)
+		for _, problem := range status.Errors {
+			fmt.Printf("   ⚠️  %s\n", problem)
+		}
+	default:
+		fmt.Printf("🛑 Stopped (%s)\n", status.State)
+		if status.Ports != nil {
+			fmt.Printf("   - Ports: %s (Web UI), %s (database)\n", status.Ports.HTTP, status.Ports.MySQL)
+		}
+		printContainerImage(status)
+		fmt.Printf("   Use 'mindsdb-cli start%s' to start the container\n", mindsdb.InstanceArg(statusInstance))
+	}
 
-		// Show available commands
-		fmt.Println("\n📋 Available Commands:")
-		fmt.Println("   mindsdb-cli start --user <user> --pass <pass>  # Start embedded MindsDB")
-		fmt.Println("   mindsdb-cli stop                               # Stop embedded MindsDB")
-		fmt.Println("   mindsdb-cli connect --embedded                 # Connect to embedded instance")
-		fmt.Println("   mindsdb-cli list-models                        # List ML models")
-		fmt.Println("   mindsdb-cli query \"SELECT * FROM models\"       # Run SQL queries")
-	},
+	// Show available commands
+	fmt.Println("\n📋 Available Commands:")
+	fmt.Println("   mindsdb-cli start [--user <user>]              # Start embedded MindsDB")
+	fmt.Println("   mindsdb-cli stop                               # Stop embedded MindsDB")
+	fmt.Println("   mindsdb-cli upgrade --version <tag>            # Upgrade embedded MindsDB")
+	fmt.Println("   mindsdb-cli instances list                     # List embedded instances")
+	fmt.Println("   mindsdb-cli connect --embedded                 # Connect to embedded instance")
+	fmt.Println("   mindsdb-cli list-models                        # List ML models")
+	fmt.Println("   mindsdb-cli query \"SELECT * FROM models\"       # Run SQL queries")
+}
+
+// printContainerImage prints the image and digest the instance's container
+// was created from, and whether it requires authentication
+func printContainerImage(status *mindsdb.InstanceStatus) {
+	fmt.Printf("   - Image: %s\n", status.Image)
+	if status.Digest != "" {
+		fmt.Printf("   - Digest: %s\n", status.Digest)
+	}
+	if status.PreviousImage != "" {
+		fmt.Printf("   - Previous image: %s (roll back with 'mindsdb-cli upgrade --rollback')\n", status.PreviousImage)
+	}
+	if status.AuthUser != "" {
+		fmt.Printf("   - Authentication: enabled (user %s)\n", status.AuthUser)
+	} else {
+		fmt.Println("   - Authentication: disabled")
+	}
+}
+
+// printAPIStatus prints the result of probing one API
+func printAPIStatus(name string, api *mindsdb.APIStatus, version string) {
+	if api == nil {
+		return
+	}
+	if !api.Reachable {
+		fmt.Printf("   - %s: ❌ %s (%s)\n", name, api.Error, api.Address)
+		return
+	}
+	fmt.Printf("   - %s: ✅ reachable in %d ms", name, api.LatencyMS)
+	if version != "" {
+		fmt.Printf(", server version %s", version)
+	}
+	fmt.Println()
+}
+
+// formatBytes renders a byte count with a binary unit, like 'docker stats'
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	value, suffix := float64(bytes)/unit, 0
+	for value >= unit && suffix < 3 {
+		value /= unit
+		suffix++
+	}
+	return fmt.Sprintf("%.1f%s", value, []string{"KiB", "MiB", "GiB", "TiB"}[suffix])
 }
 
 func init() {
-	// No flags needed for status command
+	registerInstanceFlag(statusCmd, &statusInstance)
+	statusCmd.Flags().StringVarP(&statusOutput, "output", "o", "text", "Output format: text, json or yaml")
 }
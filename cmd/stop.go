@@ -1,38 +1,62 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"mindsdb-go-cli/internal/mindsdb"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var removeContainer bool
+var stopInstance string
+var purgeData bool
+var stopForce bool
+var stopTimeout time.Duration
 
 var stopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop embedded MindsDB instance",
-	Long: `Stop the embedded MindsDB Docker container.
+	Long: `Stop the embedded MindsDB container.
     
 This command will gracefully stop the MindsDB container while preserving
 any models and data for the next time you start it.
 
+MindsDB's data lives in a named volume (mindsdb-cli-data, or
+mindsdb-cli-data-<instance>), so it also survives --remove. Use --purge to
+delete the volume and the instance's generated config (credentials) as well;
+this asks for confirmation unless --force is given.
+
+MindsDB gets 10 seconds to shut down before it is killed (the container
+runtime's default); use --timeout to give it longer, e.g. while it saves
+large models. A warning is shown when models are still training or
+generating, since stopping interrupts them.
+
 Examples:
   mindsdb-cli stop                    # Stop the container
-  mindsdb-cli stop --remove           # Stop and remove the container`,
+  mindsdb-cli stop --remove           # Stop and remove the container
+  mindsdb-cli stop --instance scratch # Stop a named instance
+  mindsdb-cli stop --timeout 2m       # Give MindsDB two minutes to shut down
+  mindsdb-cli stop --purge            # Remove the container and delete all data`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("🛑 Stopping embedded MindsDB instance...")
+		if err := mindsdb.ValidateInstanceName(stopInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		fmt.Printf("🛑 Stopping embedded MindsDB instance%s...\n", instanceSuffix(stopInstance))
 
-		// Check if Docker is available
-		if !mindsdb.IsDockerAvailable() {
-			fmt.Println("❌ Docker is not available.")
-			fmt.Println("   Please install Docker Desktop or ensure Docker daemon is running.")
+		// Check that a container runtime is available
+		rt := detectRuntime()
+		if rt == nil {
 			return
 		}
 
 		// Create a MindsDB client for container management
 		mindsdbClient := &mindsdb.MindsDBClient{
 			EmbeddedMode: true,
+			Runtime:      rt,
+			Instance:     stopInstance,
 		}
 
 		// Get container status
@@ -47,24 +71,99 @@ Examples:
 			return
 		}
 
+		if purgeData {
+			removeContainer = true
+		}
 		if !isRunning && !removeContainer {
 			fmt.Println("ℹ️  MindsDB container is not running")
 			return
 		}
 
+		// Explain what removing the container does to the data
+		if removeContainer {
+			container, err := mindsdbClient.Container()
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				return
+			}
+			volume := mindsdb.InstanceVolumeName(stopInstance)
+			switch {
+			case !mindsdbClient.HasDataVolume(container):
+				fmt.Println("⚠️  This container was created without a data volume. Removing it permanently")
+				fmt.Println("   deletes all models, databases, ML engines, jobs, knowledge bases and")
+				fmt.Printf("   uploaded files. Back them up first with 'mindsdb-cli backup%s'.\n", mindsdb.InstanceArg(stopInstance))
+				if !stopForce && !confirm("Remove the container and its data?") {
+					fmt.Println("ℹ️  Nothing removed")
+					return
+				}
+			case purgeData:
+				fmt.Printf("⚠️  --purge permanently deletes volume %s with all models, databases,\n", volume)
+				fmt.Println("   ML engines, jobs, knowledge bases and uploaded files of this instance.")
+				if !stopForce && !confirm("Delete the instance and all its data?") {
+					fmt.Println("ℹ️  Nothing removed")
+					return
+				}
+			default:
+				fmt.Printf("ℹ️  Models, databases and other data are kept in volume %s and reused by\n", volume)
+				fmt.Printf("   the next 'mindsdb-cli start%s'. Use --purge to delete them as well.\n", mindsdb.InstanceArg(stopInstance))
+			}
+		}
+
+		if isRunning {
+			warnBusyModels(mindsdbClient, "stopping")
+		}
+
 		// Stop the container (and optionally remove it)
-		if err := mindsdbClient.StopEmbeddedMindsDB(removeContainer); err != nil {
+		if err := mindsdbClient.StopEmbeddedMindsDB(removeContainer, stopTimeoutFlag(cmd, stopTimeout)); err != nil {
 			fmt.Printf("❌ Failed to stop container: %v\n", err)
 			return
 		}
+		if purgeData {
+			if err := mindsdbClient.PurgeData(); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				return
+			}
+			fmt.Printf("🗑️  Deleted data volume %s\n", mindsdb.InstanceVolumeName(stopInstance))
+		}
 
 		if !removeContainer {
-			fmt.Println("💡 Use 'mindsdb-cli start' to start it again")
-			fmt.Println("💡 Use 'mindsdb-cli stop --remove' to remove the container completely")
+			fmt.Printf("💡 Use 'mindsdb-cli start%s' to start it again\n", mindsdb.InstanceArg(stopInstance))
+			fmt.Printf("💡 Use 'mindsdb-cli stop --remove%s' to remove the container completely\n", mindsdb.InstanceArg(stopInstance))
 		}
 	},
 }
 
+// stopTimeoutFlag returns the --timeout value, or nil to use the runtime's
+// default when the flag was not given
+func stopTimeoutFlag(cmd *cobra.Command, timeout time.Duration) *time.Duration {
+	if !cmd.Flags().Changed("timeout") {
+		return nil
+	}
+	return &timeout
+}
+
+// warnBusyModels warns about models that are still training or generating,
+// since stopping the container interrupts them. The check is best effort:
+// an instance that does not answer SQL is stopped without a warning.
+func warnBusyModels(client *mindsdb.MindsDBClient, action string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	models, err := client.BusyModels(ctx)
+	if err != nil || len(models) == 0 {
+		return
+	}
+
+	fmt.Printf("⚠️  %d model(s) are still training or generating; %s MindsDB interrupts them:\n", len(models), action)
+	for _, model := range models {
+		fmt.Printf("   - %s.%s (%s)\n", model.Project, model.Name, model.Status)
+	}
+	fmt.Println("   Wait until they are complete, or use --timeout to give MindsDB more time to shut down.")
+}
+
 func init() {
-	stopCmd.Flags().BoolVar(&removeContainer, "remove", false, "Remove the container after stopping")
+	registerInstanceFlag(stopCmd, &stopInstance)
+	stopCmd.Flags().BoolVar(&removeContainer, "remove", false, "Remove the container after stopping (data in the volume is kept)")
+	stopCmd.Flags().BoolVar(&purgeData, "purge", false, "Remove the container and delete its data volume and generated config")
+	stopCmd.Flags().BoolVar(&stopForce, "force", false, "Do not ask for confirmation before deleting data")
+	stopCmd.Flags().DurationVar(&stopTimeout, "timeout", 10*time.Second, "How long MindsDB may take to shut down before it is killed")
 }
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var upgradeInstance string
+var upgradeVersion string
+var upgradeRollback bool
+var upgradeWaitTimeout time.Duration
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade the embedded MindsDB image",
+	Long: `Upgrade an embedded instance to another MindsDB version.
+
+The requested tag is pulled and the container is recreated from it on the
+same data volume and host ports. If the tag resolves to the digest the
+instance already runs, nothing changes.
+
+The previous image is recorded on the container, and --rollback recreates
+the container from it. MindsDB may migrate its storage when a new version
+starts, so take a 'mindsdb-cli backup' before upgrading if you may need to
+roll back.
+
+Examples:
+  mindsdb-cli upgrade                          # Latest MindsDB
+  mindsdb-cli upgrade --version v25.1.2
+  mindsdb-cli upgrade --rollback
+  mindsdb-cli upgrade --instance scratch --version v25.2.0`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mindsdb.ValidateInstanceName(upgradeInstance); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if err := mindsdb.ValidateVersion(upgradeVersion); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		if upgradeRollback && cmd.Flags().Changed("version") {
+			fmt.Println("❌ --rollback and --version cannot be used together")
+			return
+		}
+		rt := detectRuntime()
+		if rt == nil {
+			return
+		}
+
+		client := &mindsdb.MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: upgradeInstance}
+		opts := mindsdb.EmbeddedOptions{
+			WaitTimeout: upgradeWaitTimeout,
+			Instance:    upgradeInstance,
+			Version:     upgradeVersion,
+		}
+
+		var result *mindsdb.UpgradeResult
+		var err error
+		if upgradeRollback {
+			fmt.Printf("⏪ Rolling back embedded MindsDB%s...\n", instanceSuffix(upgradeInstance))
+			result, err = client.RollbackEmbeddedMindsDB(context.Background(), opts)
+		} else {
+			fmt.Printf("⬆️  Upgrading embedded MindsDB%s to %s...\n", instanceSuffix(upgradeInstance), mindsdb.ImageRef(upgradeVersion))
+			result, err = client.UpgradeEmbeddedMindsDB(context.Background(), opts)
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			var readinessErr *mindsdb.ReadinessError
+			if errors.As(err, &readinessErr) && !upgradeRollback {
+				fmt.Printf("💡 Use 'mindsdb-cli upgrade --rollback%s' to return to %s\n", mindsdb.InstanceArg(upgradeInstance), result.From)
+			}
+			return
+		}
+
+		if result.AlreadyCurrent {
+			fmt.Printf("✅ Already running %s (%s)\n", result.To, result.ToDigest)
+			return
+		}
+		fmt.Printf("✅ Now running %s\n", result.To)
+		fmt.Printf("   - From: %s (%s)\n", result.From, result.FromDigest)
+		fmt.Printf("   - To:   %s (%s)\n", result.To, result.ToDigest)
+		if !upgradeRollback {
+			fmt.Printf("💡 Use 'mindsdb-cli upgrade --rollback%s' to return to the previous version\n", mindsdb.InstanceArg(upgradeInstance))
+		}
+	},
+}
+
+func init() {
+	registerInstanceFlag(upgradeCmd, &upgradeInstance)
+	upgradeCmd.Flags().StringVar(&upgradeVersion, "version", mindsdb.DefaultVersion, "MindsDB image tag to upgrade to")
+	upgradeCmd.Flags().BoolVar(&upgradeRollback, "rollback", false, "Recreate the container from the image it ran before the last upgrade")
+	upgradeCmd.Flags().DurationVar(&upgradeWaitTimeout, "wait-timeout", mindsdb.DefaultWaitTimeout, "How long to wait for MindsDB to accept SQL connections")
+}
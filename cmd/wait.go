@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"mindsdb-go-cli/internal/mindsdb"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var waitConn connectionFlags
+var waitTimeout time.Duration
+var waitProbe string
+
+var waitCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Wait until a MindsDB instance accepts SQL connections",
+	Long: `Block until a MindsDB instance (embedded or external) accepts SQL
+connections, then exit. Useful in CI pipelines before running queries.
+
+The same readiness checks as 'start' are used: the embedded container must be
+running, the port must accept TCP connections and a SQL connection must
+succeed. An optional --probe statement must also succeed, e.g. to wait until
+the models catalog is available.
+
+The command exits with a non-zero status if the instance is not ready
+before --timeout expires.
+
+Examples:
+  mindsdb-cli wait                                          # Embedded instance
+  mindsdb-cli wait --timeout 5m --probe "SELECT 1 FROM models"
+  mindsdb-cli wait --profile staging
+  mindsdb-cli wait --protocol http --host mindsdb.internal:47334`,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := waitConn.resolve(cmd)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		wait := mindsdb.WaitOptions{
+			Timeout: waitTimeout,
+			Probe:   waitProbe,
+			OnRetry: func(phase string, err error) {
+				fmt.Printf("   … %s: %v\n", phase, err)
+			},
+		}
+
+		start := time.Now()
+		if settings.Embedded || settings.Host == "" {
+			fmt.Printf("⏳ Waiting for embedded MindsDB (timeout %s)...\n", waitTimeout)
+			err = mindsdb.WaitForEmbedded(mindsdb.EmbeddedOptions{
+				User:     settings.User,
+				Password: settings.Password,
+				Runtime:  runtimeName,
+				Instance: settings.Instance,
+			}, wait)
+		} else {
+			fmt.Printf("⏳ Waiting for MindsDB at %s (timeout %s)...\n", settings.Host, waitTimeout)
+			err = mindsdb.WaitForExternal(settings.ConnectOptions, wait)
+		}
+
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ MindsDB is ready (after %s)\n", time.Since(start).Round(time.Second))
+	},
+}
+
+func init() {
+	waitConn.register(waitCmd)
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", mindsdb.DefaultWaitTimeout, "Maximum time to wait")
+	waitCmd.Flags().StringVar(&waitProbe, "probe", "", "SQL statement that must succeed, e.g. \"SELECT 1 FROM models\"")
+}
@@ -10,6 +10,7 @@ require (
 	github.com/jackc/pgx/v5 v5.5.4
 	github.com/spf13/cobra v1.6.1
 	golang.org/x/term v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// AppName is the directory name used under the user's config directory
+	AppName = "mindsdb-cli"
+	// EnvConfigPath overrides the location of the config file
+	EnvConfigPath = "MINDSDB_CLI_CONFIG"
+)
+
+// Profile is a named set of connection settings. Passwords are never stored
+// in the profile itself, only the path of a file holding one.
+type Profile struct {
+	Host         string `yaml:"host,omitempty"`
+	User         string `yaml:"user,omitempty"`
+	Protocol     string `yaml:"protocol,omitempty"`
+	Database     string `yaml:"database,omitempty"`
+	Embedded     bool   `yaml:"embedded,omitempty"`
+	Instance     string `yaml:"instance,omitempty"` // Embedded instance name
+	PasswordFile string `yaml:"password_file,omitempty"`
+	TLS          TLS    `yaml:"tls,omitempty"`
+}
+
+// TLS holds the encryption settings of a profile
+type TLS struct {
+	Mode       string `yaml:"sslmode,omitempty"`
+	CAFile     string `yaml:"ca_file,omitempty"`
+	CertFile   string `yaml:"cert_file,omitempty"`
+	KeyFile    string `yaml:"key_file,omitempty"`
+	ServerName string `yaml:"server_name,omitempty"`
+}
+
+// Config is the content of the CLI config file
+type Config struct {
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+
+	path string
+}
+
+// Dir returns the CLI config directory, honoring XDG_CONFIG_HOME
+// (defaults to ~/.config/mindsdb-cli)
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, AppName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", AppName), nil
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config file, creating its directory if needed
+func (c *Config) Save() error {
+	if c.path == "" {
+		path, err := Path()
+		if err != nil {
+			return err
+		}
+		c.path = path
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// File returns the path the config was loaded from
+func (c *Config) File() string {
+	return c.path
+}
+
+// Profile returns the named profile
+func (c *Config) Profile(name string) (*Profile, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", name, c.path)
+	}
+	return profile, nil
+}
+
+// SetProfile creates or replaces a profile
+func (c *Config) SetProfile(name string, profile *Profile) {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+	c.Profiles[name] = profile
+}
+
+// DeleteProfile removes a profile, clearing it as current if needed
+func (c *Config) DeleteProfile(name string) error {
+	if _, err := c.Profile(name); err != nil {
+		return err
+	}
+	delete(c.Profiles, name)
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+	}
+	return nil
+}
+
+// ProfileNames returns the profile names in alphabetical order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
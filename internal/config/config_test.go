@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useConfigFile points the config at a file in a temporary directory
+func useConfigFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	t.Setenv(EnvConfigPath, path)
+	return path
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	path := useConfigFile(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.CurrentProfile != "" || len(cfg.Profiles) != 0 {
+		t.Errorf("Load() = %+v, want an empty config", cfg)
+	}
+	if cfg.File() != path {
+		t.Errorf("File() = %q, want %q", cfg.File(), path)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := useConfigFile(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod := &Profile{
+		Host:         "db.example.com:5432",
+		User:         "admin",
+		Protocol:     "postgres",
+		Database:     "sales",
+		PasswordFile: "/run/secrets/mindsdb",
+		TLS:          TLS{Mode: "verify-full", CAFile: "/etc/ssl/ca.pem", ServerName: "mindsdb.internal"},
+	}
+	local := &Profile{Embedded: true, Instance: "scratch"}
+	cfg.SetProfile("prod", prod)
+	cfg.SetProfile("local", local)
+	cfg.CurrentProfile = "prod"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("config file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.CurrentProfile != "prod" {
+		t.Errorf("CurrentProfile = %q, want prod", loaded.CurrentProfile)
+	}
+	if names := loaded.ProfileNames(); strings.Join(names, ",") != "local,prod" {
+		t.Errorf("ProfileNames() = %v", names)
+	}
+	for name, want := range map[string]*Profile{"prod": prod, "local": local} {
+		got, err := loaded.Profile(name)
+		if err != nil {
+			t.Fatalf("Profile(%q) error = %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Profile(%q) = %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestUnknownProfile(t *testing.T) {
+	path := useConfigFile(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.SetProfile("prod", &Profile{Host: "db.example.com"})
+
+	_, err = cfg.Profile("staging")
+	if err == nil || !strings.Contains(err.Error(), `profile "staging" not found in `+path) {
+		t.Errorf("Profile() error = %v", err)
+	}
+	if err := cfg.DeleteProfile("staging"); err == nil {
+		t.Error("DeleteProfile() of an unknown profile succeeded")
+	}
+}
+
+func TestDeleteCurrentProfile(t *testing.T) {
+	useConfigFile(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.SetProfile("prod", &Profile{Host: "db.example.com"})
+	cfg.CurrentProfile = "prod"
+
+	if err := cfg.DeleteProfile("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.CurrentProfile != "" || len(cfg.Profiles) != 0 {
+		t.Errorf("after DeleteProfile() config = %+v", cfg)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := useConfigFile(t)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("profiles: [not, a, map]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "failed to parse "+path) {
+		t.Errorf("Load() error = %v", err)
+	}
+}
+
+func TestPathHonorsXDG(t *testing.T) {
+	t.Setenv(EnvConfigPath, "")
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/tmp/xdg/mindsdb-cli/config.yaml" {
+		t.Errorf("Path() = %q", path)
+	}
+}
@@ -0,0 +1,184 @@
+package mindsdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"mindsdb-go-cli/internal/config"
+)
+
+const (
+	// AuthUserLabel records the user an instance with authentication expects
+	AuthUserLabel = "mindsdb-cli.auth-user"
+	// ConfigMountPath is where the generated MindsDB config is mounted
+	ConfigMountPath = "/root/mindsdb-cli-config.json"
+	// DefaultUser is the user MindsDB accepts when authentication is disabled
+	DefaultUser = "mindsdb"
+)
+
+// mindsdbConfig is the part of MindsDB's config file the CLI generates
+type mindsdbConfig struct {
+	Auth struct {
+		HTTPAuthEnabled bool   `json:"http_auth_enabled"`
+		Username        string `json:"username"`
+		Password        string `json:"password"`
+	} `json:"auth"`
+}
+
+// InstanceConfigDir returns the directory holding the instance's generated
+// files (~/.config/mindsdb-cli/instances/<name>)
+func InstanceConfigDir(instance string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "instances", instanceName(instance)), nil
+}
+
+// instanceConfigFile returns the path of the instance's MindsDB config
+func instanceConfigFile(instance string) (string, error) {
+	dir, err := InstanceConfigDir(instance)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// writeInstanceConfig writes a MindsDB config enabling authentication for
+// user and returns its path. It holds the password, so only the current
+// user may read it.
+func writeInstanceConfig(instance, user, pass string) (string, error) {
+	file, err := instanceConfigFile(instance)
+	if err != nil {
+		return "", err
+	}
+
+	var cfg mindsdbConfig
+	cfg.Auth.HTTPAuthEnabled = true
+	cfg.Auth.Username = user
+	cfg.Auth.Password = pass
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create instance config directory: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write MindsDB config: %w", err)
+	}
+	return file, nil
+}
+
+// instancePassword reads the password stored for user in the instance's
+// generated config
+func instancePassword(instance, user string) (string, error) {
+	file, err := instanceConfigFile(instance)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("the instance requires authentication as %q but its config %s is missing; pass --user and a password", user, file)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read MindsDB config: %w", err)
+	}
+
+	var cfg mindsdbConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if cfg.Auth.Username != user {
+		return "", fmt.Errorf("%s is for user %q but the instance expects %q; pass --user and a password", file, cfg.Auth.Username, user)
+	}
+	return cfg.Auth.Password, nil
+}
+
+// RemoveInstanceConfig deletes the instance's generated files
+func RemoveInstanceConfig(instance string) error {
+	dir, err := InstanceConfigDir(instance)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// AuthUser returns the user a container requires, or "" when it was
+// created without authentication
+func AuthUser(info *ContainerInfo) string {
+	return info.Labels[AuthUserLabel]
+}
+
+// embeddedCredential is the user/password pair used for the embedded
+// instance
+type embeddedCredential struct {
+	user  string
+	pass  string
+	label string
+}
+
+// credentialFor returns the credentials a container expects. Containers
+// created without authentication accept MindsDB's default user; for the
+// others the provided credentials are used when given, and the ones stored
+// by 'start' otherwise.
+func (c *MindsDBClient) credentialFor(info *ContainerInfo, user, pass string) (embeddedCredential, error) {
+	authUser := AuthUser(info)
+	switch {
+	case authUser == "":
+		return embeddedCredential{user: DefaultUser, label: "MindsDB defaults (authentication disabled)"}, nil
+	case user != "" && pass != "":
+		return embeddedCredential{user: user, pass: pass, label: "provided credentials"}, nil
+	}
+
+	stored, err := instancePassword(c.Instance, authUser)
+	if err != nil {
+		return embeddedCredential{}, err
+	}
+	return embeddedCredential{user: authUser, pass: stored, label: "stored credentials"}, nil
+}
+
+// openEmbedded opens a SQL connection to a running embedded container
+func (c *MindsDBClient) openEmbedded(ctx context.Context, container *ContainerInfo) (*sqlExecutor, error) {
+	cred, err := c.credentialFor(container, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return newMySQLExecutor(ctx, mysqlConfig(cred.user, cred.pass, "localhost:"+containerPorts(container).MySQL, "mindsdb", nil))
+}
+
+// authDrift describes how the requested authentication differs from the
+// one the container was created with, or returns "" if it does not
+func (c *MindsDBClient) authDrift(info *ContainerInfo, opts EmbeddedOptions) string {
+	current := AuthUser(info)
+	switch {
+	case current == "":
+		return fmt.Sprintf("auth: disabled → user %s", opts.User)
+	case current != opts.User:
+		return fmt.Sprintf("auth: user %s → %s", current, opts.User)
+	}
+	if stored, err := instancePassword(c.Instance, current); err != nil || stored != opts.Password {
+		return fmt.Sprintf("auth: password of %s changed", current)
+	}
+	return ""
+}
+
+// keepAuth makes opts recreate the authentication of an existing container
+func (c *MindsDBClient) keepAuth(info *ContainerInfo, opts *EmbeddedOptions) error {
+	user := AuthUser(info)
+	if user == "" {
+		opts.Auth = false
+		return nil
+	}
+	pass, err := instancePassword(c.Instance, user)
+	if err != nil {
+		return err
+	}
+	opts.Auth, opts.User, opts.Password = true, user, pass
+	return nil
+}
@@ -0,0 +1,259 @@
+package mindsdb
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Container returns the instance's container, or nil if it does not exist
+func (c *MindsDBClient) Container() (*ContainerInfo, error) {
+	return c.inspectExisting()
+}
+
+// HasDataVolume reports whether a container keeps MindsDB's storage in the
+// instance's named volume. Containers created by older versions of the CLI
+// keep it in the container itself, so removing them loses all data.
+func (c *MindsDBClient) HasDataVolume(container *ContainerInfo) bool {
+	return container.Volumes[InstanceVolumeName(c.Instance)] == StoragePath
+}
+
+// DataVolumeExists reports whether the instance's data volume exists. It
+// outlives the container, e.g. after 'stop --remove'.
+func (c *MindsDBClient) DataVolumeExists(ctx context.Context) (bool, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return false, err
+	}
+	exists, err := rt.VolumeExists(ctx, InstanceVolumeName(c.Instance))
+	if err != nil {
+		return false, fmt.Errorf("failed to look up data volume %s: %w", InstanceVolumeName(c.Instance), err)
+	}
+	return exists, nil
+}
+
+// BackupEmbeddedMindsDB writes a gzip-compressed tar archive of the
+// instance's MindsDB storage directory to w. The container may be stopped.
+// When only the data volume is left, it is read through a temporary
+// container that is never started.
+func (c *MindsDBClient) BackupEmbeddedMindsDB(ctx context.Context, w io.Writer) error {
+	container, err := c.inspectExisting()
+	if err != nil {
+		return err
+	}
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+
+	id := ""
+	if container != nil {
+		id = container.ID
+	} else {
+		exists, err := c.DataVolumeExists(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("neither MindsDB container %s nor data volume %s exists", c.containerName(), InstanceVolumeName(c.Instance))
+		}
+		if id, err = c.createVolumeReader(ctx); err != nil {
+			return err
+		}
+		defer rt.RemoveContainer(context.Background(), id)
+	}
+
+	archive, err := rt.CopyFromContainer(ctx, id, StoragePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s from the container: %w", StoragePath, err)
+	}
+	defer archive.Close()
+
+	gz := gzip.NewWriter(w)
+	if _, err := io.Copy(gz, archive); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return gz.Close()
+}
+
+// createVolumeReader creates, without starting, a container that mounts the
+// instance's data volume so that its files can be copied out
+func (c *MindsDBClient) createVolumeReader(ctx context.Context) (string, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+	image, err := c.resolveImage(ctx, ImageRef(""), false)
+	if err != nil {
+		return "", err
+	}
+
+	// A reader left behind by an interrupted backup would block the name
+	name := c.containerName() + "-backup"
+	if _, err := rt.InspectContainer(ctx, name); err == nil {
+		if err := rt.RemoveContainer(ctx, name); err != nil {
+			return "", fmt.Errorf("failed to remove leftover container %s: %w", name, err)
+		}
+	}
+
+	fmt.Printf("📦 Reading data volume %s through temporary container %s...\n", InstanceVolumeName(c.Instance), name)
+	id, err := rt.CreateContainer(ctx, ContainerSpec{
+		Name:    name,
+		Image:   image.pinned(),
+		Volumes: map[string]string{InstanceVolumeName(c.Instance): StoragePath},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create container to read the data volume: %w", err)
+	}
+	return id, nil
+}
+
+// ValidateBackup checks that file is a backup created by
+// BackupEmbeddedMindsDB
+func ValidateBackup(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s is not a gzip-compressed backup: %w", file, err)
+	}
+	header, err := tar.NewReader(gz).Next()
+	if err != nil {
+		return fmt.Errorf("%s is not a valid backup: %w", file, err)
+	}
+
+	root := path.Base(StoragePath)
+	if name := strings.TrimPrefix(header.Name, "./"); name != root && !strings.HasPrefix(name, root+"/") {
+		return fmt.Errorf("%s is not a MindsDB backup (expected %s/, found %s)", file, root, header.Name)
+	}
+	return nil
+}
+
+// RestoreEmbeddedMindsDB replaces the instance's data with a backup created
+// by BackupEmbeddedMindsDB. The existing container and data volume are
+// removed, a fresh container is created, the archive is copied into it and
+// the instance is started. The existing ports are kept unless opts.Ports is
+// set. Everything that can be checked up front is checked before anything
+// is removed.
+func (c *MindsDBClient) RestoreEmbeddedMindsDB(ctx context.Context, file string, opts EmbeddedOptions) (string, error) {
+	if err := ValidateBackup(file); err != nil {
+		return "", err
+	}
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := c.inspectExisting()
+	if err != nil {
+		return "", err
+	}
+
+	// Keep the image the instance ran, or use the requested version for a
+	// new instance
+	var image embeddedImage
+	if existing != nil {
+		image = imageFromContainer(existing)
+		err = c.ensureImage(ctx, image)
+	} else {
+		image, err = c.resolveImage(ctx, ImageRef(opts.Version), opts.Pull)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var removed []string
+	if existing != nil {
+		current := containerPorts(existing)
+		if opts.Ports == (Ports{}) {
+			opts.Ports = current
+		}
+		opts.Container = containerOptions(existing)
+		if err := c.keepAuth(existing, &opts); err != nil {
+			return "", err
+		}
+		// A running container holds its own ports; any other port must be
+		// free, or the new container could not be created after the data
+		// is gone
+		if ports := opts.Ports.withDefaults(); !existing.Running || ports != current {
+			if err := checkPortsFree(ports); err != nil {
+				return "", fmt.Errorf("cannot restore: %w; nothing was removed", err)
+			}
+		}
+
+		fmt.Println("🗑️  Removing the existing MindsDB container...")
+		if err := rt.StopContainer(ctx, existing.ID, nil); err != nil {
+			return "", fmt.Errorf("failed to stop container: %w", err)
+		}
+		if err := rt.RemoveContainer(ctx, existing.ID); err != nil {
+			return "", fmt.Errorf("failed to remove container: %w", err)
+		}
+		removed = append(removed, "container "+existing.Name)
+	}
+
+	// From here on the previous instance is gone; say so on failure, since
+	// the backup is all that is left
+	lost := func(err error) error {
+		if len(removed) == 0 {
+			return err
+		}
+		return fmt.Errorf("%w (removed %s; %s is unchanged, so the restore can be retried)", err, strings.Join(removed, " and "), file)
+	}
+	volume := InstanceVolumeName(c.Instance)
+	if err := rt.RemoveVolume(ctx, volume); err != nil {
+		return "", lost(fmt.Errorf("failed to remove data volume: %w", err))
+	}
+	removed = append(removed, "data volume "+volume)
+
+	containerID, err := c.createEmbeddedContainer(ctx, opts, image, nil)
+	if err != nil {
+		return "", lost(err)
+	}
+
+	fmt.Println("📦 Restoring data into the new container...")
+	f, err := os.Open(file)
+	if err != nil {
+		return "", lost(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", lost(err)
+	}
+	if err := rt.CopyToContainer(ctx, containerID, path.Dir(StoragePath), gz); err != nil {
+		return "", lost(fmt.Errorf("failed to copy backup into the container: %w", err))
+	}
+
+	if err := rt.StartContainer(ctx, containerID); err != nil {
+		return "", fmt.Errorf("failed to start container: %w", err)
+	}
+	if err := c.waitForMindsDB(containerID, opts); err != nil {
+		return "", err
+	}
+	return containerID, nil
+}
+
+// PurgeData removes the instance's data volume and generated config. The
+// container must have been removed first.
+func (c *MindsDBClient) PurgeData() error {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+	if err := rt.RemoveVolume(context.Background(), InstanceVolumeName(c.Instance)); err != nil {
+		return fmt.Errorf("failed to remove data volume: %w", err)
+	}
+	if err := RemoveInstanceConfig(c.Instance); err != nil {
+		return fmt.Errorf("failed to remove instance config: %w", err)
+	}
+	return nil
+}
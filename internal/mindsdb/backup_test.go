@@ -0,0 +1,96 @@
+package mindsdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBackupFromContainer(t *testing.T) {
+	rt := newFakeRuntime(&ContainerInfo{
+		Name:    ContainerName,
+		Status:  "exited",
+		Volumes: map[string]string{DataVolume: StoragePath},
+	})
+	rt.archive = []byte("mdb_storage/")
+	client := &MindsDBClient{Runtime: rt}
+
+	var out bytes.Buffer
+	if err := client.BackupEmbeddedMindsDB(context.Background(), &out); err != nil {
+		t.Fatalf("BackupEmbeddedMindsDB() error = %v", err)
+	}
+	if got := gunzip(t, &out); got != "mdb_storage/" {
+		t.Errorf("backup = %q", got)
+	}
+	want := "copy " + StoragePath + " from " + ContainerName
+	if strings.Join(rt.calls, "|") != want {
+		t.Errorf("calls = %v, want %s", rt.calls, want)
+	}
+}
+
+func TestBackupFromVolumeWithoutContainer(t *testing.T) {
+	rt := newFakeRuntime()
+	rt.volumes[DataVolume+"-scratch"] = true
+	rt.images[ImageRef("")] = &ImageInfo{ID: "sha256:local"}
+	rt.archive = []byte("mdb_storage/")
+	client := &MindsDBClient{Runtime: rt, Instance: "scratch"}
+
+	var out bytes.Buffer
+	if err := client.BackupEmbeddedMindsDB(context.Background(), &out); err != nil {
+		t.Fatalf("BackupEmbeddedMindsDB() error = %v", err)
+	}
+	if got := gunzip(t, &out); got != "mdb_storage/" {
+		t.Errorf("backup = %q", got)
+	}
+
+	// The temporary container is never started and is removed afterwards
+	reader := ContainerName + "-scratch-backup"
+	want := []string{"create " + reader, "copy " + StoragePath + " from " + reader, "remove " + reader}
+	if strings.Join(rt.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %v, want %v", rt.calls, want)
+	}
+	if !rt.volumes[DataVolume+"-scratch"] {
+		t.Error("the data volume was removed")
+	}
+}
+
+func TestBackupWithoutData(t *testing.T) {
+	rt := newFakeRuntime()
+	client := &MindsDBClient{Runtime: rt}
+
+	err := client.BackupEmbeddedMindsDB(context.Background(), io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "neither MindsDB container "+ContainerName+" nor data volume "+DataVolume+" exists") {
+		t.Errorf("BackupEmbeddedMindsDB() error = %v", err)
+	}
+	if len(rt.calls) != 0 {
+		t.Errorf("calls = %v, want none", rt.calls)
+	}
+}
+
+func TestDataVolumeExists(t *testing.T) {
+	rt := newFakeRuntime()
+	rt.volumes[DataVolume] = true
+
+	for instance, want := range map[string]bool{"": true, "scratch": false} {
+		client := &MindsDBClient{Runtime: rt, Instance: instance}
+		if got, err := client.DataVolumeExists(context.Background()); err != nil || got != want {
+			t.Errorf("instance %q: DataVolumeExists() = %v, %v; want %v", instance, got, err, want)
+		}
+	}
+}
+
+func gunzip(t *testing.T, r io.Reader) string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -2,243 +2,525 @@ package mindsdb
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
-	"os/exec"
-	"strings"
+	"net/url"
 	"time"
-
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/jackc/pgx/v5"
 )
 
 const (
-	MindsDBImage  = "mindsdb/mindsdb:latest"
+	MindsDBImage  = MindsDBRepository + ":" + DefaultVersion
 	ContainerName = "mindsdb-cli-embedded"
 	MindsDBPort   = "47334"
 	MySQLPort     = "47335" // MindsDB uses MySQL protocol
 )
 
+// Wire protocols supported for external connections
+const (
+	ProtocolPostgres = "postgres"
+	ProtocolMySQL    = "mysql"
+	ProtocolHTTP     = "http"
+)
+
 type MindsDBClient struct {
-	PgConn       *pgx.Conn // For PostgreSQL connections (external)
-	MySQLConn    *sql.DB   // For MySQL connections (embedded)
+	Conn         Executor // Active connection, independent of wire protocol
 	ContainerID  string
 	EmbeddedMode bool
-	IsMySQL      bool
+	Runtime      Runtime // Container runtime; detected on first use when nil
+	Ports        Ports   // Host ports of the embedded container
+	Instance     string  // Embedded instance name; DefaultInstance when empty
+}
+
+// ConnectOptions describes an external MindsDB connection
+type ConnectOptions struct {
+	Protocol string // One of ProtocolPostgres, ProtocolMySQL or ProtocolHTTP
+	Host     string
+	User     string
+	Password string
+	Database string // Defaults to "mindsdb"
+	TLS      TLSOptions
 }
 
 // NewClient creates a client for external MindsDB connection (PostgreSQL)
 func NewClient(host, user, pass string) (*MindsDBClient, error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s/mindsdb", user, pass, host)
-	conn, err := pgx.Connect(context.Background(), dsn)
+	return Connect(ConnectOptions{Protocol: ProtocolPostgres, Host: host, User: user, Password: pass})
+}
+
+// Connect creates a client for an external MindsDB instance using the
+// requested wire protocol
+func Connect(opts ConnectOptions) (*MindsDBClient, error) {
+	return ConnectContext(context.Background(), opts)
+}
+
+// ConnectContext is like Connect but gives up when ctx is done
+func ConnectContext(ctx context.Context, opts ConnectOptions) (*MindsDBClient, error) {
+	database := opts.Database
+	if database == "" {
+		database = "mindsdb"
+	}
+
+	tlsHost := opts.Host
+	if u, err := url.Parse(opts.Host); err == nil && u.Host != "" {
+		tlsHost = u.Host // HTTP hosts may be given as full URLs
+	}
+	tlsConfig, err := opts.TLS.Config(tlsHost)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn Executor
+
+	switch opts.Protocol {
+	case "", ProtocolPostgres:
+		dsn := &url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(opts.User, opts.Password),
+			Host:   opts.Host,
+			Path:   "/" + database,
+		}
+		if opts.TLS.Mode == SSLModeDisable || tlsConfig != nil {
+			dsn.RawQuery = "sslmode=disable" // tlsConfig, if any, is applied by the executor
+		}
+		conn, err = newPostgresExecutor(ctx, dsn.String(), tlsConfig)
+	case ProtocolMySQL:
+		conn, err = newMySQLExecutor(ctx, mysqlConfig(opts.User, opts.Password, opts.Host, database, tlsConfig))
+	case ProtocolHTTP:
+		conn, err = newHTTPExecutor(ctx, opts.Host, opts.User, opts.Password, database, tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q (use postgres, mysql or http)", opts.Protocol)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MindsDB: %w", err)
 	}
-	return &MindsDBClient{PgConn: conn, EmbeddedMode: false, IsMySQL: false}, nil
+	return &MindsDBClient{Conn: conn, EmbeddedMode: false}, nil
 }
 
-// NewEmbeddedClient creates a client with embedded MindsDB using Docker CLI
-func NewEmbeddedClient(user, pass string) (*MindsDBClient, error) {
-	// Check if Docker is available
-	if !IsDockerAvailable() {
-		return nil, fmt.Errorf("Docker is not available - required for embedded mode")
+// EmbeddedOptions configures the embedded MindsDB instance
+type EmbeddedOptions struct {
+	User         string
+	Password     string
+	WaitTimeout  time.Duration    // DefaultWaitTimeout when zero
+	Runtime      string           // Container runtime name; auto-detected when empty
+	Ports        Ports            // Host ports for a new container; defaults when empty
+	AutoPort     bool             // Pick free ports instead of failing when taken
+	Instance     string           // Embedded instance name; DefaultInstance when empty
+	Version      string           // MindsDB image tag for a new container; DefaultVersion when empty
+	ImageArchive string           // Image tar archive to load instead of pulling
+	Pull         bool             // Pull the image even if it is present locally
+	Container    ContainerOptions // Resource limits, environment, network and mounts
+	Recreate     bool             // Recreate an existing container with Container instead of reporting drift
+	Auth         bool             // Enable authentication with User and Password on a new container
+}
+
+// NewEmbeddedClient creates a client with embedded MindsDB running in a
+// container
+func NewEmbeddedClient(opts EmbeddedOptions) (*MindsDBClient, error) {
+	// Check that a container runtime is available
+	rt, err := DetectRuntime(opts.Runtime)
+	if err != nil {
+		return nil, fmt.Errorf("a container runtime is required for embedded mode: %w", err)
 	}
 
-	client := &MindsDBClient{EmbeddedMode: true, IsMySQL: true}
+	if err := ValidateInstanceName(opts.Instance); err != nil {
+		return nil, err
+	}
+	client := &MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: opts.Instance}
 
 	// Start the container if not running
-	containerID, err := client.StartEmbeddedMindsDB(user, pass)
+	containerID, err := client.StartEmbeddedMindsDB(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start embedded MindsDB: %w", err)
 	}
 	client.ContainerID = containerID
 
-	// Try connecting without credentials first (MindsDB default behavior)
-	fmt.Println("🔐 Trying connection with MindsDB defaults (user: mindsdb, no password)...")
-	mysqlDSN := fmt.Sprintf("mindsdb:@tcp(localhost:%s)/mindsdb", MySQLPort)
-
-	mysqlConn, err := sql.Open("mysql", mysqlDSN)
-	if err == nil {
-		if err = mysqlConn.Ping(); err == nil {
-			fmt.Println("✅ Connected successfully with MindsDB defaults")
-			client.MySQLConn = mysqlConn
-			return client, nil
-		}
-		mysqlConn.Close()
+	// Connect with the credentials the instance was created with
+	info, err := client.Container()
+	if err != nil {
+		return nil, err
 	}
-	fmt.Println("❌ Failed with MindsDB defaults, trying with provided credentials...")
-
-	// If no-auth fails, try with provided credentials
-	if user != "" && pass != "" {
-		fmt.Printf("🔐 Trying provided credentials (%s)...\n", user)
-		mysqlDSN = fmt.Sprintf("%s:%s@tcp(localhost:%s)/mindsdb", user, pass, MySQLPort)
-
-		mysqlConn, err = sql.Open("mysql", mysqlDSN)
-		if err == nil {
-			if err = mysqlConn.Ping(); err == nil {
-				fmt.Printf("✅ Connected successfully with provided credentials\n")
-				client.MySQLConn = mysqlConn
-				return client, nil
-			}
-			mysqlConn.Close()
-		}
-		fmt.Printf("❌ Failed with provided credentials\n")
+	cred, err := client.credentialFor(info, opts.User, opts.Password)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("🔐 Connecting with %s (user: %s)...\n", cred.label, cred.user)
+	conn, err := newMySQLExecutor(context.Background(), mysqlConfig(cred.user, cred.pass, "localhost:"+client.Ports.MySQL, "mindsdb", nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MindsDB as %s: %w", cred.user, err)
 	}
+	client.Conn = conn
+	return client, nil
+}
 
-	return nil, fmt.Errorf("failed to connect to MindsDB. Default credentials are user 'mindsdb' with empty password. Last error: %w", err)
+// Query executes a SQL query on the active connection. Cancelling ctx
+// aborts the query.
+func (c *MindsDBClient) Query(ctx context.Context, query string) (Rows, error) {
+	if c.Conn == nil {
+		return nil, fmt.Errorf("no valid connection available")
+	}
+	return c.Conn.Query(ctx, query)
 }
 
-// Query executes a SQL query on the appropriate connection
-func (c *MindsDBClient) Query(query string) (*sql.Rows, error) {
-	if c.IsMySQL && c.MySQLConn != nil {
-		return c.MySQLConn.Query(query)
-	} else if c.PgConn != nil {
-		// For PostgreSQL, we need to handle this differently
-		return nil, fmt.Errorf("PostgreSQL query execution needs implementation")
+// Exec executes a SQL statement that does not return rows
+func (c *MindsDBClient) Exec(ctx context.Context, query string) error {
+	if c.Conn == nil {
+		return fmt.Errorf("no valid connection available")
 	}
-	return nil, fmt.Errorf("no valid connection available")
+	return c.Conn.Exec(ctx, query)
 }
 
-// QueryPg executes a PostgreSQL query (for external connections)
-func (c *MindsDBClient) QueryPg(query string) (pgx.Rows, error) {
-	if c.PgConn == nil {
-		return nil, fmt.Errorf("no PostgreSQL connection available")
+// Ping verifies that the active connection is alive
+func (c *MindsDBClient) Ping(ctx context.Context) error {
+	if c.Conn == nil {
+		return fmt.Errorf("no valid connection available")
 	}
-	return c.PgConn.Query(context.Background(), query)
+	return c.Conn.Ping(ctx)
 }
 
-// IsDockerAvailable checks if Docker is installed and running
-func IsDockerAvailable() bool {
-	cmd := exec.Command("docker", "version")
-	err := cmd.Run()
-	return err == nil
+// containerRuntime returns the client's container runtime, detecting one on
+// first use
+func (c *MindsDBClient) containerRuntime() (Runtime, error) {
+	if c.Runtime == nil {
+		rt, err := DetectRuntime("")
+		if err != nil {
+			return nil, err
+		}
+		c.Runtime = rt
+	}
+	return c.Runtime, nil
 }
 
-// StartEmbeddedMindsDB starts a MindsDB container using Docker CLI
-func (c *MindsDBClient) StartEmbeddedMindsDB(user, pass string) (string, error) {
+// StartEmbeddedMindsDB starts a MindsDB container with the client's runtime
+func (c *MindsDBClient) StartEmbeddedMindsDB(opts EmbeddedOptions) (string, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+
 	// Check if container already exists and is running
-	if containerID := c.findExistingContainer(); containerID != "" {
-		if c.isContainerRunning(containerID) {
-			fmt.Println("✅ MindsDB container is already running")
-			return containerID, nil
+	existing, err := c.inspectExisting()
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		c.Ports = containerPorts(existing)
+		if requested := opts.Ports.withDefaults(); opts.Ports != (Ports{}) && requested != c.Ports {
+			fmt.Printf("⚠️  The existing container publishes ports %s (HTTP) and %s (MySQL); ports are fixed when the container is created\n", c.Ports.HTTP, c.Ports.MySQL)
+		}
+		if current := imageFromContainer(existing); opts.Version != "" && ImageRef(opts.Version) != current.Ref {
+			fmt.Printf("⚠️  The existing container runs %s; use 'mindsdb-cli upgrade --version %s%s' to switch\n", current.Ref, opts.Version, InstanceArg(c.Instance))
+		}
+		if opts.ImageArchive != "" {
+			fmt.Println("ℹ️  The container already exists; --image-archive is only used when creating it")
 		}
 
-		// Container exists but not running, start it
-		fmt.Println("▶️  Starting existing MindsDB container...")
-		if err := c.startContainer(containerID); err == nil {
-			if err := c.waitForMindsDB(user, pass); err != nil {
+		// Options and authentication are only compared when requested, so a
+		// plain start keeps whatever the container was created with
+		var changes []string
+		if !opts.Container.IsZero() || opts.Recreate {
+			changes = containerOptions(existing).Diff(opts.Container)
+		}
+		if opts.Auth {
+			if change := c.authDrift(existing, opts); change != "" {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) > 0 || opts.Recreate {
+			if !opts.Recreate {
+				return "", &ConfigDriftError{Container: existing.Name, Changes: changes}
+			}
+			if err := c.recreateWithOptions(ctx, existing, opts); err != nil {
 				return "", err
 			}
-			return containerID, nil
+			return c.ContainerID, nil
+		}
+
+		if existing.Running {
+			fmt.Println("✅ MindsDB container is already running")
+			return existing.ID, nil
+		}
+
+		// Container exists but not running; make sure its ports are still
+		// free so the runtime does not fail with an obscure error
+		if err := checkPortsFree(c.Ports); err != nil {
+			return "", fmt.Errorf("cannot start existing container: %w (remove it with 'mindsdb-cli stop --remove%s' to choose other ports)", err, InstanceArg(c.Instance))
 		}
-	}
 
-	// Pull the image
-	fmt.Println("📥 Pulling MindsDB Docker image...")
-	cmd := exec.Command("docker", "pull", MindsDBImage)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to pull MindsDB image: %w", err)
+		// Start it
+		fmt.Println("▶️  Starting existing MindsDB container...")
+		if err := c.startContainer(existing.ID); err != nil {
+			return "", fmt.Errorf("failed to start existing container: %w", err)
+		}
+		if err := c.waitForMindsDB(existing.ID, opts); err != nil {
+			return "", err
+		}
+		return existing.ID, nil
 	}
 
-	// Create and start container
-	fmt.Println("🚀 Creating MindsDB container...")
-	cmd = exec.Command("docker", "run", "-d",
-		"--name", ContainerName,
-		"-p", MindsDBPort+":"+MindsDBPort,
-		"-p", MySQLPort+":"+MySQLPort,
-		"-e", "MINDSDB_DB_SERVICE_HOST=0.0.0.0",
-		"-e", "MINDSDB_DB_SERVICE_PORT="+MySQLPort,
-		MindsDBImage)
-
-	output, err := cmd.Output()
+	ref := ImageRef(opts.Version)
+	if opts.ImageArchive != "" {
+		if ref, err = c.loadImageArchive(ctx, opts.ImageArchive, opts.Version); err != nil {
+			return "", err
+		}
+	}
+	image, err := c.resolveImage(ctx, ref, opts.Pull && opts.ImageArchive == "")
 	if err != nil {
-		return "", fmt.Errorf("failed to create container: %w", err)
+		return "", err
+	}
+	containerID, err := c.createEmbeddedContainer(ctx, opts, image, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := rt.StartContainer(ctx, containerID); err != nil {
+		return "", fmt.Errorf("failed to start container: %w", err)
 	}
-
-	containerID := strings.TrimSpace(string(output))
 	fmt.Println("✅ MindsDB container started successfully")
 
 	// Wait for MindsDB to be ready
-	if err := c.waitForMindsDB(user, pass); err != nil {
+	if err := c.waitForMindsDB(containerID, opts); err != nil {
 		return "", err
 	}
 
 	return containerID, nil
 }
 
-// findExistingContainer looks for an existing MindsDB container
-func (c *MindsDBClient) findExistingContainer() string {
-	cmd := exec.Command("docker", "ps", "-a", "--filter", "name="+ContainerName, "--format", "{{.ID}}")
-	output, err := cmd.Output()
+// createEmbeddedContainer creates, without starting, the instance's
+// container from image with its labels, data volume and ports. Extra labels
+// are added to the standard ones.
+func (c *MindsDBClient) createEmbeddedContainer(ctx context.Context, opts EmbeddedOptions, image embeddedImage, extraLabels map[string]string) (string, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+
+	// Make sure the host ports are free before creating anything. Named
+	// instances get free ports unless asked otherwise, so they never clash
+	// with the default instance.
+	var ports Ports
+	if instanceName(c.Instance) != DefaultInstance && opts.Ports == (Ports{}) {
+		ports, err = freePorts()
+	} else {
+		ports, err = resolvePorts(opts.Ports, opts.AutoPort)
+	}
 	if err != nil {
-		return ""
+		return "", err
 	}
+	c.Ports = ports
 
-	containerID := strings.TrimSpace(string(output))
-	return containerID
+	labels := map[string]string{
+		InstanceLabel: instanceName(c.Instance),
+		ImageLabel:    image.Ref,
+		DigestLabel:   image.Digest,
+		PortsLabel:    ports.label(),
+	}
+	if !opts.Container.IsZero() {
+		labels[OptionsLabel] = opts.Container.label()
+	}
+
+	env := []string{
+		"MINDSDB_DB_SERVICE_HOST=0.0.0.0",
+		"MINDSDB_DB_SERVICE_PORT=" + MySQLPort,
+	}
+	mounts := append([]string(nil), opts.Container.Mounts...)
+	if opts.Auth {
+		file, err := writeInstanceConfig(c.Instance, opts.User, opts.Password)
+		if err != nil {
+			return "", err
+		}
+		labels[AuthUserLabel] = opts.User
+		env = append(env, "MINDSDB_CONFIG_PATH="+ConfigMountPath)
+		mounts = append(mounts, file+":"+ConfigMountPath+":ro")
+	}
+	for key, value := range extraLabels {
+		labels[key] = value
+	}
+
+	// Create the container
+	fmt.Println("🚀 Creating MindsDB container...")
+	containerID, err := rt.CreateContainer(ctx, ContainerSpec{
+		Name:   c.containerName(),
+		Image:  image.pinned(),
+		Labels: labels,
+		Volumes: map[string]string{
+			InstanceVolumeName(c.Instance): StoragePath,
+		},
+		Env: append(env, opts.Container.Env...),
+		Ports: map[string]string{
+			MindsDBPort: ports.HTTP,
+			MySQLPort:   ports.MySQL,
+		},
+		CPUs:    opts.Container.CPUs,
+		Memory:  opts.Container.Memory,
+		Network: opts.Container.Network,
+		Mounts:  mounts,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create container: %w", err)
+	}
+	return containerID, nil
+}
+
+// inspectExisting looks up the MindsDB container, returning nil if there is
+// none
+func (c *MindsDBClient) inspectExisting() (*ContainerInfo, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := rt.InspectContainer(context.Background(), c.containerName())
+	if errors.Is(err, ErrContainerNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up container %s: %w", c.containerName(), err)
+	}
+	return container, nil
+}
+
+// findExistingContainer looks for an existing MindsDB container and returns
+// its ID, or an empty string if there is none
+func (c *MindsDBClient) findExistingContainer() (string, error) {
+	container, err := c.inspectExisting()
+	if err != nil || container == nil {
+		return "", err
+	}
+	return container.ID, nil
 }
 
 // isContainerRunning checks if a container is currently running
 func (c *MindsDBClient) isContainerRunning(containerID string) bool {
-	cmd := exec.Command("docker", "ps", "--filter", "id="+containerID, "--format", "{{.ID}}")
-	output, err := cmd.Output()
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return false
+	}
+
+	container, err := rt.InspectContainer(context.Background(), containerID)
 	if err != nil {
 		return false
 	}
-	return strings.TrimSpace(string(output)) != ""
+	return container.Running
 }
 
 // startContainer starts an existing container
 func (c *MindsDBClient) startContainer(containerID string) error {
-	cmd := exec.Command("docker", "start", containerID)
-	return cmd.Run()
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+	return rt.StartContainer(context.Background(), containerID)
 }
 
-// waitForMindsDB waits for MindsDB to be ready to accept connections
-func (c *MindsDBClient) waitForMindsDB(user, pass string) error {
+// waitForMindsDB waits for MindsDB to be ready to accept connections,
+// using the readiness checks described in waitUntilReady
+func (c *MindsDBClient) waitForMindsDB(containerID string, opts EmbeddedOptions) error {
 	fmt.Print("⏳ Waiting for MindsDB to be ready")
 
-	mysqlDSN := fmt.Sprintf("%s:%s@tcp(localhost:%s)/mindsdb", user, pass, MySQLPort)
-
-	maxAttempts := 30
-	for i := 1; i <= maxAttempts; i++ {
-		db, err := sql.Open("mysql", mysqlDSN)
-		if err == nil {
-			if err := db.Ping(); err == nil {
-				db.Close()
-				fmt.Println(" ✅")
-				fmt.Printf("🎉 MindsDB is ready! Web UI: http://localhost:%s\n", MindsDBPort)
-				return nil
-			}
-			db.Close()
+	err := waitUntilReady(opts.WaitTimeout, func(ctx context.Context) (string, error) {
+		phase, err := c.checkReady(ctx, containerID, opts.User, opts.Password, "")
+		if phase == PhaseContainerUp {
+			// A stopped container will not come up on its own
+			return phase, &permanentError{err: err}
 		}
-
+		return phase, err
+	}, func(string, error) {
 		fmt.Print(".")
-		time.Sleep(2 * time.Second)
+	})
+	if err != nil {
+		fmt.Println(" ❌")
+		return err
+	}
+
+	fmt.Println(" ✅")
+	fmt.Printf("🎉 MindsDB is ready! Web UI: http://localhost:%s\n", c.Ports.HTTP)
+	return nil
+}
+
+// checkReady runs one readiness attempt against the embedded container and
+// returns the phase that failed along with its error
+func (c *MindsDBClient) checkReady(ctx context.Context, containerID, user, pass, probe string) (string, error) {
+	notRunning := fmt.Errorf("container %s is not running (check '%s logs %s')", c.containerName(), c.runtimeName(), c.containerName())
+	if containerID == "" {
+		return PhaseContainerUp, notRunning
+	}
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return PhaseContainerUp, err
 	}
+	container, err := rt.InspectContainer(ctx, containerID)
+	if err != nil || !container.Running {
+		return PhaseContainerUp, notRunning
+	}
+
+	addr := "localhost:" + containerPorts(container).MySQL
+	if err := checkPortOpen(ctx, addr); err != nil {
+		return PhasePortOpen, err
+	}
+
+	cred, err := c.credentialFor(container, user, pass)
+	if err != nil {
+		return PhaseSQLPing, &permanentError{err: err}
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	conn, err := newMySQLExecutor(pingCtx, mysqlConfig(cred.user, cred.pass, addr, "mindsdb", nil))
+	cancel()
+	if err != nil {
+		return PhaseSQLPing, err
+	}
+	defer conn.Close()
+	if err := runProbe(ctx, conn, probe); err != nil {
+		return PhaseSQLProbe, err
+	}
+	return "", nil
+}
 
-	fmt.Println(" ❌")
-	return fmt.Errorf("MindsDB did not become ready after %d seconds", maxAttempts*2)
+// WaitForEmbedded blocks until the embedded MindsDB container accepts SQL
+// connections, using the same credentials as NewEmbeddedClient
+func WaitForEmbedded(opts EmbeddedOptions, wait WaitOptions) error {
+	rt, err := DetectRuntime(opts.Runtime)
+	if err != nil {
+		return err
+	}
+	if err := ValidateInstanceName(opts.Instance); err != nil {
+		return err
+	}
+	c := &MindsDBClient{EmbeddedMode: true, Runtime: rt, Instance: opts.Instance}
+
+	return waitUntilReady(wait.Timeout, func(ctx context.Context) (string, error) {
+		containerID, err := c.findExistingContainer()
+		if err != nil {
+			return PhaseContainerUp, err
+		}
+		return c.checkReady(ctx, containerID, opts.User, opts.Password, wait.Probe)
+	}, wait.OnRetry)
 }
 
-// StopEmbeddedMindsDB stops the MindsDB container
-func (c *MindsDBClient) StopEmbeddedMindsDB(remove bool) error {
-	containerID := c.findExistingContainer()
+// StopEmbeddedMindsDB stops the MindsDB container, giving MindsDB timeout
+// to shut down before it is killed (the runtime default when nil)
+func (c *MindsDBClient) StopEmbeddedMindsDB(remove bool, timeout *time.Duration) error {
+	containerID, err := c.findExistingContainer()
+	if err != nil {
+		return err
+	}
 	if containerID == "" {
 		return fmt.Errorf("MindsDB container not found")
 	}
 
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
 	// Stop the container
 	fmt.Println("🛑 Stopping MindsDB container...")
-	cmd := exec.Command("docker", "stop", containerID)
-	if err := cmd.Run(); err != nil {
+	if err := rt.StopContainer(ctx, containerID, timeout); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 
 	if remove {
 		// Remove the container
-		cmd = exec.Command("docker", "rm", containerID)
-		if err := cmd.Run(); err != nil {
+		if err := rt.RemoveContainer(ctx, containerID); err != nil {
 			return fmt.Errorf("failed to remove container: %w", err)
 		}
 		fmt.Println("🗑️  MindsDB container stopped and removed")
@@ -249,33 +531,70 @@ func (c *MindsDBClient) StopEmbeddedMindsDB(remove bool) error {
 	return nil
 }
 
-// GetContainerStatus returns the status of the MindsDB container
+// RestartEmbeddedMindsDB stops the MindsDB container if it is running,
+// starts it again and waits until it accepts SQL connections
+func (c *MindsDBClient) RestartEmbeddedMindsDB(opts EmbeddedOptions, timeout *time.Duration) error {
+	existing, err := c.inspectExisting()
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("MindsDB container %s not found (use 'mindsdb-cli start%s' first)", c.containerName(), InstanceArg(c.Instance))
+	}
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if existing.Running {
+		fmt.Println("🛑 Stopping MindsDB container...")
+		if err := rt.StopContainer(ctx, existing.ID, timeout); err != nil {
+			return fmt.Errorf("failed to stop container: %w", err)
+		}
+	}
+
+	c.Ports = containerPorts(existing)
+	fmt.Println("▶️  Starting MindsDB container...")
+	if err := rt.StartContainer(ctx, existing.ID); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	c.ContainerID = existing.ID
+	return c.waitForMindsDB(existing.ID, opts)
+}
+
+// GetContainerStatus returns the status of the MindsDB container and
+// records its published ports in c.Ports
 func (c *MindsDBClient) GetContainerStatus() (bool, string, error) {
-	containerID := c.findExistingContainer()
-	if containerID == "" {
+	container, err := c.inspectExisting()
+	if err != nil {
+		return false, "", err
+	}
+	if container == nil {
 		return false, "", nil // Container doesn't exist
 	}
 
-	// Check if running
-	isRunning := c.isContainerRunning(containerID)
+	c.Ports = containerPorts(container)
+	return container.Running, container.StartedAt, nil
+}
 
-	// Get start time
-	cmd := exec.Command("docker", "inspect", containerID, "--format", "{{.State.StartedAt}}")
-	output, err := cmd.Output()
-	startedAt := ""
-	if err == nil {
-		startedAt = strings.TrimSpace(string(output))
-	}
+// containerName returns the name of the client's embedded container
+func (c *MindsDBClient) containerName() string {
+	return InstanceContainerName(c.Instance)
+}
 
-	return isRunning, startedAt, nil
+// runtimeName returns the name of the client's runtime for messages,
+// falling back to docker before one has been detected
+func (c *MindsDBClient) runtimeName() string {
+	if c.Runtime == nil {
+		return RuntimeDocker
+	}
+	return c.Runtime.Name()
 }
 
-// Close closes the client connections
+// Close closes the client connection
 func (c *MindsDBClient) Close() {
-	if c.PgConn != nil {
-		c.PgConn.Close(context.Background())
-	}
-	if c.MySQLConn != nil {
-		c.MySQLConn.Close()
+	if c.Conn != nil {
+		c.Conn.Close()
 	}
 }
@@ -0,0 +1,548 @@
+package mindsdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDockerHost is the daemon address used when DOCKER_HOST is unset
+const DefaultDockerHost = "unix:///var/run/docker.sock"
+
+// DockerClient talks to the Docker Engine API over a unix socket or TCP,
+// following the same DOCKER_HOST, DOCKER_TLS_VERIFY and DOCKER_CERT_PATH
+// conventions as the docker CLI
+type DockerClient struct {
+	host    string
+	baseURL string
+	http    *http.Client
+}
+
+// DockerError is an error reported by the Docker daemon
+type DockerError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *DockerError) Error() string {
+	return e.Message
+}
+
+// IsDockerNotFound reports whether err is a daemon "not found" error
+func IsDockerNotFound(err error) bool {
+	var dockerErr *DockerError
+	return errors.As(err, &dockerErr) && dockerErr.StatusCode == http.StatusNotFound
+}
+
+// NewDockerClient creates a client for the daemon selected by DOCKER_HOST
+func NewDockerClient() (*DockerClient, error) {
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		host = DefaultDockerHost
+	}
+	return NewDockerClientForHost(host)
+}
+
+// NewDockerClientForHost creates a client for a daemon address such as
+// unix:///var/run/docker.sock or tcp://127.0.0.1:2375
+func NewDockerClientForHost(host string) (*DockerClient, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Docker host %q: %w", host, err)
+	}
+
+	transport := &http.Transport{
+		MaxIdleConns:    2,
+		IdleConnTimeout: 30 * time.Second,
+	}
+	client := &DockerClient{host: host, http: &http.Client{Transport: transport}}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		client.baseURL = "http://docker"
+	case "tcp", "http", "https":
+		scheme := "http"
+		if u.Scheme == "https" || os.Getenv("DOCKER_TLS_VERIFY") != "" {
+			scheme = "https"
+			tlsConfig, err := dockerTLSConfig(u.Host)
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		client.baseURL = scheme + "://" + u.Host
+	default:
+		return nil, fmt.Errorf("unsupported Docker host %q (use unix:// or tcp://)", host)
+	}
+
+	return client, nil
+}
+
+// dockerTLSConfig loads the certificates from DOCKER_CERT_PATH
+// (~/.docker by default), like the docker CLI does
+func dockerTLSConfig(host string) (*tls.Config, error) {
+	certPath := os.Getenv("DOCKER_CERT_PATH")
+	if certPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		certPath = filepath.Join(home, ".docker")
+	}
+
+	return TLSOptions{
+		Mode:     SSLModeVerifyFull,
+		CAFile:   filepath.Join(certPath, "ca.pem"),
+		CertFile: filepath.Join(certPath, "cert.pem"),
+		KeyFile:  filepath.Join(certPath, "key.pem"),
+	}.Config(host)
+}
+
+// do sends a request to the daemon. Non-2xx responses are turned into a
+// DockerError carrying the daemon's message; the caller must close the body
+// of successful responses.
+func (d *DockerClient) do(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	contentType := "application/json"
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		// Raw uploads, such as tar archives
+		reader = b
+		contentType = "application/x-tar"
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	endpoint := d.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the Docker daemon at %s: %w", d.host, err)
+	}
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotModified {
+		defer resp.Body.Close()
+		return nil, dockerErrorFrom(resp)
+	}
+	return resp, nil
+}
+
+// dockerErrorFrom decodes the {"message": "..."} body of a failed request
+func dockerErrorFrom(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &payload) == nil && payload.Message != "" {
+		message = payload.Message
+	}
+	if message == "" {
+		message = resp.Status
+	}
+	return &DockerError{StatusCode: resp.StatusCode, Message: message}
+}
+
+// doJSON sends a request and decodes the JSON response into out (if not nil)
+func (d *DockerClient) doJSON(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	resp, err := d.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Ping checks that the daemon is reachable
+func (d *DockerClient) Ping(ctx context.Context) error {
+	return d.doJSON(ctx, http.MethodGet, "/_ping", nil, nil, nil)
+}
+
+// ServerVersion returns the daemon's version
+func (d *DockerClient) ServerVersion(ctx context.Context) (string, error) {
+	var version struct {
+		Version string `json:"Version"`
+	}
+	if err := d.doJSON(ctx, http.MethodGet, "/version", nil, nil, &version); err != nil {
+		return "", err
+	}
+	return version.Version, nil
+}
+
+// ImagePull pulls an image such as mindsdb/mindsdb:latest. The daemon
+// streams progress messages; an error in any of them fails the pull.
+func (d *DockerClient) ImagePull(ctx context.Context, ref string) error {
+	image, tag := splitImageRef(ref)
+	query := url.Values{"fromImage": {image}, "tag": {tag}}
+
+	resp, err := d.do(ctx, http.MethodPost, "/images/create", query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var message struct {
+			Error       string `json:"error"`
+			ErrorDetail struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := decoder.Decode(&message); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read pull progress: %w", err)
+		}
+
+		if message.ErrorDetail.Message != "" {
+			return &DockerError{StatusCode: http.StatusInternalServerError, Message: message.ErrorDetail.Message}
+		}
+		if message.Error != "" {
+			return &DockerError{StatusCode: http.StatusInternalServerError, Message: message.Error}
+		}
+	}
+}
+
+// splitImageRef splits "repo:tag" into its parts, defaulting the tag to
+// latest. Registry ports ("host:5000/repo") are not mistaken for tags.
+func splitImageRef(ref string) (string, string) {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		return ref[:i], ref[i+1:] // The API accepts a digest as the tag
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, "latest"
+}
+
+// ImageLoad loads images from a tar archive, as produced by ImageSave, and
+// returns the daemon's messages (e.g. "Loaded image: mindsdb/mindsdb:latest")
+func (d *DockerClient) ImageLoad(ctx context.Context, archive io.Reader) (string, error) {
+	resp, err := d.do(ctx, http.MethodPost, "/images/load", nil, archive)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var output strings.Builder
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var message struct {
+			Stream      string `json:"stream"`
+			Error       string `json:"error"`
+			ErrorDetail struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := decoder.Decode(&message); err == io.EOF {
+			return output.String(), nil
+		} else if err != nil {
+			return "", fmt.Errorf("failed to read load progress: %w", err)
+		}
+
+		if message.ErrorDetail.Message != "" {
+			return "", &DockerError{StatusCode: http.StatusInternalServerError, Message: message.ErrorDetail.Message}
+		}
+		if message.Error != "" {
+			return "", &DockerError{StatusCode: http.StatusInternalServerError, Message: message.Error}
+		}
+		output.WriteString(message.Stream)
+	}
+}
+
+// ImageSave returns a tar archive of an image
+func (d *DockerClient) ImageSave(ctx context.Context, ref string) (io.ReadCloser, error) {
+	resp, err := d.do(ctx, http.MethodGet, "/images/get", url.Values{"names": {ref}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ImageJSON is the subset of the image inspect response the CLI uses
+type ImageJSON struct {
+	ID          string   `json:"Id"`
+	RepoTags    []string `json:"RepoTags"`
+	RepoDigests []string `json:"RepoDigests"`
+	Created     string   `json:"Created"`
+	Size        int64    `json:"Size"`
+}
+
+// ImageInspect returns details about a local image. Missing images yield an
+// error for which IsDockerNotFound is true.
+func (d *DockerClient) ImageInspect(ctx context.Context, ref string) (*ImageJSON, error) {
+	var image ImageJSON
+	if err := d.doJSON(ctx, http.MethodGet, "/images/"+ref+"/json", nil, nil, &image); err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// PortBinding maps a container port to a host address
+type PortBinding struct {
+	HostIP   string `json:"HostIp,omitempty"`
+	HostPort string `json:"HostPort"`
+}
+
+// ContainerConfig is the body of a container create request
+type ContainerConfig struct {
+	Image        string              `json:"Image"`
+	Env          []string            `json:"Env,omitempty"`
+	Labels       map[string]string   `json:"Labels,omitempty"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+	HostConfig   HostConfig          `json:"HostConfig"`
+}
+
+// HostConfig holds the host-specific settings of a container
+type HostConfig struct {
+	PortBindings map[string][]PortBinding `json:"PortBindings,omitempty"`
+	Binds        []string                 `json:"Binds,omitempty"` // volume:path, named volumes are created on demand
+	NanoCpus     int64                    `json:"NanoCpus,omitempty"`
+	Memory       int64                    `json:"Memory,omitempty"`
+	NetworkMode  string                   `json:"NetworkMode,omitempty"`
+}
+
+// ContainerCreate creates (but does not start) a container and returns its ID
+func (d *DockerClient) ContainerCreate(ctx context.Context, name string, config ContainerConfig) (string, error) {
+	var created struct {
+		ID string `json:"Id"`
+	}
+	query := url.Values{"name": {name}}
+	if err := d.doJSON(ctx, http.MethodPost, "/containers/create", query, config, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// ContainerStart starts a created or stopped container
+func (d *DockerClient) ContainerStart(ctx context.Context, id string) error {
+	return d.doJSON(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/start", nil, nil, nil)
+}
+
+// ContainerStop stops a container. A nil timeout uses the daemon default.
+func (d *DockerClient) ContainerStop(ctx context.Context, id string, timeout *time.Duration) error {
+	query := url.Values{}
+	if timeout != nil {
+		query.Set("t", strconv.Itoa(int(timeout.Seconds())))
+	}
+	return d.doJSON(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/stop", query, nil, nil)
+}
+
+// ContainerRemove removes a stopped container
+func (d *DockerClient) ContainerRemove(ctx context.Context, id string) error {
+	return d.doJSON(ctx, http.MethodDelete, "/containers/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ContainerList returns the IDs of all containers, running or not, that
+// match the given filters (e.g. {"label": {"key=value"}})
+func (d *DockerClient) ContainerList(ctx context.Context, filters map[string][]string) ([]string, error) {
+	query := url.Values{"all": {"1"}}
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	var containers []struct {
+		ID string `json:"Id"`
+	}
+	if err := d.doJSON(ctx, http.MethodGet, "/containers/json", query, nil, &containers); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
+
+// CopyFromContainer returns a tar archive of path inside a container. The
+// container does not need to be running.
+func (d *DockerClient) CopyFromContainer(ctx context.Context, id, path string) (io.ReadCloser, error) {
+	resp, err := d.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/archive", url.Values{"path": {path}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CopyToContainer extracts a tar archive into dir inside a container
+func (d *DockerClient) CopyToContainer(ctx context.Context, id, dir string, archive io.Reader) error {
+	return d.doJSON(ctx, http.MethodPut, "/containers/"+url.PathEscape(id)+"/archive", url.Values{"path": {dir}}, archive, nil)
+}
+
+// VolumeInspect checks that a named volume exists
+func (d *DockerClient) VolumeInspect(ctx context.Context, name string) error {
+	return d.doJSON(ctx, http.MethodGet, "/volumes/"+url.PathEscape(name), nil, nil, nil)
+}
+
+// VolumeRemove removes a named volume
+func (d *DockerClient) VolumeRemove(ctx context.Context, name string) error {
+	return d.doJSON(ctx, http.MethodDelete, "/volumes/"+url.PathEscape(name), nil, nil, nil)
+}
+
+// ContainerJSON is the subset of the container inspect response the CLI uses
+type ContainerJSON struct {
+	ID      string `json:"Id"`
+	Name    string `json:"Name"`
+	Image   string `json:"Image"`
+	Created string `json:"Created"`
+	State   struct {
+		Status     string `json:"Status"`
+		Running    bool   `json:"Running"`
+		ExitCode   int    `json:"ExitCode"`
+		Error      string `json:"Error"`
+		StartedAt  string `json:"StartedAt"`
+		FinishedAt string `json:"FinishedAt"`
+		Health     *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+	Config struct {
+		Image  string            `json:"Image"`
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+		Tty    bool              `json:"Tty"`
+	} `json:"Config"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+	HostConfig struct {
+		PortBindings map[string][]PortBinding `json:"PortBindings"`
+	} `json:"HostConfig"`
+	NetworkSettings struct {
+		Ports map[string][]PortBinding `json:"Ports"` // Empty while the container is stopped
+	} `json:"NetworkSettings"`
+}
+
+// ContainerInspect returns details about a container, looked up by ID or
+// name. Missing containers yield an error for which IsDockerNotFound is true.
+func (d *DockerClient) ContainerInspect(ctx context.Context, id string) (*ContainerJSON, error) {
+	var container ContainerJSON
+	if err := d.doJSON(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/json", nil, nil, &container); err != nil {
+		return nil, err
+	}
+	return &container, nil
+}
+
+// StatsJSON is the part of a container stats sample the CLI uses
+type StatsJSON struct {
+	CPUStats    cpuStats `json:"cpu_stats"`
+	PreCPUStats cpuStats `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage int64            `json:"usage"`
+		Limit int64            `json:"limit"`
+		Stats map[string]int64 `json:"stats"`
+	} `json:"memory_stats"`
+}
+
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// ContainerStats returns one stats sample of a running container. The
+// daemon fills in the previous CPU sample, so CPU usage can be computed
+// from a single response.
+func (d *DockerClient) ContainerStats(ctx context.Context, id string) (*StatsJSON, error) {
+	var stats StatsJSON
+	query := url.Values{"stream": {"false"}}
+	if err := d.doJSON(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/stats", query, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ContainerLogs streams the logs of a container. Unless the container has a
+// TTY, the daemon multiplexes stdout and stderr; the returned reader
+// removes that framing.
+func (d *DockerClient) ContainerLogs(ctx context.Context, id string, query url.Values, tty bool) (io.ReadCloser, error) {
+	resp, err := d.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/logs", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tty {
+		return resp.Body, nil
+	}
+	return &demuxReader{source: resp.Body}, nil
+}
+
+// demuxReader strips the 8-byte frame headers ([stream, 0, 0, 0, size])
+// from a multiplexed log stream, merging stdout and stderr
+type demuxReader struct {
+	source    io.ReadCloser
+	remaining int
+}
+
+func (r *demuxReader) Read(p []byte) (int, error) {
+	for r.remaining == 0 {
+		var header [8]byte
+		if _, err := io.ReadFull(r.source, header[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return 0, err
+		}
+		r.remaining = int(binary.BigEndian.Uint32(header[4:]))
+	}
+
+	if len(p) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.source.Read(p)
+	r.remaining -= n
+	return n, err
+}
+
+func (r *demuxReader) Close() error {
+	return r.source.Close()
+}
@@ -0,0 +1,308 @@
+package mindsdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// newFakeDocker serves handler on a unix socket and returns a client for it,
+// selected through DOCKER_HOST like a real daemon
+func newFakeDocker(t *testing.T, handler http.Handler) *DockerClient {
+	t.Helper()
+
+	// Socket paths are limited to about 100 bytes, so avoid t.TempDir
+	dir, err := os.MkdirTemp("", "docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "docker.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http.Server{Handler: handler}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	t.Setenv("DOCKER_HOST", "unix://"+socket)
+	client, err := NewDockerClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestDockerClientPing(t *testing.T) {
+	client := newFakeDocker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_ping" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "OK")
+	}))
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() = %v", err)
+	}
+}
+
+func TestDockerErrorSurfacesDaemonMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		message  string
+		notFound bool
+	}{
+		{
+			name:    "json message",
+			status:  http.StatusInternalServerError,
+			body:    `{"message":"driver failed programming external connectivity: port is already allocated"}`,
+			message: "driver failed programming external connectivity: port is already allocated",
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			body:     `{"message":"No such container: mindsdb-default"}`,
+			message:  "No such container: mindsdb-default",
+			notFound: true,
+		},
+		{
+			name:    "plain text body",
+			status:  http.StatusBadRequest,
+			body:    "bad parameter\n",
+			message: "bad parameter",
+		},
+		{
+			name:    "empty body",
+			status:  http.StatusConflict,
+			message: "409 Conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDocker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+
+			err := client.ContainerStart(context.Background(), "mindsdb-default")
+			var dockerErr *DockerError
+			if !errors.As(err, &dockerErr) {
+				t.Fatalf("ContainerStart() = %v, want a *DockerError", err)
+			}
+			if dockerErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", dockerErr.StatusCode, tt.status)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.message)
+			}
+			if IsDockerNotFound(err) != tt.notFound {
+				t.Errorf("IsDockerNotFound() = %v, want %v", IsDockerNotFound(err), tt.notFound)
+			}
+		})
+	}
+}
+
+func TestDockerRuntimeInspectMissingContainer(t *testing.T) {
+	client := newFakeDocker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"No such container: mindsdb-default"}`)
+	}))
+	rt := &dockerRuntime{docker: client}
+
+	if _, err := rt.InspectContainer(context.Background(), "mindsdb-default"); !errors.Is(err, ErrContainerNotFound) {
+		t.Errorf("InspectContainer() = %v, want ErrContainerNotFound", err)
+	}
+}
+
+func TestDockerClientUnreachable(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "unix:///nonexistent/docker.sock")
+	client, err := NewDockerClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot connect to the Docker daemon at unix:///nonexistent/docker.sock") {
+		t.Errorf("Ping() = %v, want an error naming the daemon address", err)
+	}
+}
+
+func TestDockerImagePull(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		err    string
+	}{
+		{
+			name: "success",
+			stream: `{"status":"Pulling from mindsdb/mindsdb","id":"latest"}
+{"status":"Downloading","progressDetail":{"current":1,"total":2},"id":"abc"}
+{"status":"Status: Downloaded newer image for mindsdb/mindsdb:latest"}
+`,
+		},
+		{
+			name: "error detail",
+			stream: `{"status":"Pulling from mindsdb/mindsdb","id":"v9"}
+{"errorDetail":{"message":"manifest for mindsdb/mindsdb:v9 not found: manifest unknown"},"error":"manifest unknown"}
+`,
+			err: "manifest for mindsdb/mindsdb:v9 not found: manifest unknown",
+		},
+		{
+			name:   "error only",
+			stream: `{"error":"toomanyrequests: rate limit exceeded"}`,
+			err:    "toomanyrequests: rate limit exceeded",
+		},
+		{
+			name:   "truncated stream",
+			stream: `{"status":"Downloading"`,
+			err:    "failed to read pull progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromImage, tag string
+			client := newFakeDocker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/images/create" {
+					http.NotFound(w, r)
+					return
+				}
+				fromImage, tag = r.URL.Query().Get("fromImage"), r.URL.Query().Get("tag")
+				// Pull errors arrive in the stream of a successful response
+				io.WriteString(w, tt.stream)
+			}))
+
+			err := client.ImagePull(context.Background(), "mindsdb/mindsdb:v9")
+			if fromImage != "mindsdb/mindsdb" || tag != "v9" {
+				t.Errorf("pulled fromImage=%q tag=%q, want mindsdb/mindsdb and v9", fromImage, tag)
+			}
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("ImagePull() = %v, want nil", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("ImagePull() = %v, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+// logFrame encodes one frame of a multiplexed log stream
+func logFrame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+func TestDemuxReader(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write(logFrame(1, "INFO starting\n"))
+	stream.Write(logFrame(2, "ERROR boom\nTraceback\n"))
+	stream.Write(logFrame(1, ""))
+	stream.Write(logFrame(1, "INFO done\n"))
+	want := "INFO starting\nERROR boom\nTraceback\nINFO done\n"
+
+	tests := []struct {
+		name   string
+		reader io.Reader
+	}{
+		{"whole frames", bytes.NewReader(stream.Bytes())},
+		// Headers and payloads split across reads, as over a network
+		{"one byte at a time", iotest.OneByteReader(bytes.NewReader(stream.Bytes()))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(&demuxReader{source: io.NopCloser(tt.reader)})
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != want {
+				t.Errorf("ReadAll() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDemuxReaderTruncatedHeader(t *testing.T) {
+	stream := append(logFrame(1, "INFO starting\n"), 1, 0, 0)
+	got, err := io.ReadAll(&demuxReader{source: io.NopCloser(bytes.NewReader(stream))})
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v, want a clean end of stream", err)
+	}
+	if string(got) != "INFO starting\n" {
+		t.Errorf("ReadAll() = %q", got)
+	}
+}
+
+func TestDockerContainerLogsDemultiplexes(t *testing.T) {
+	client := newFakeDocker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/mindsdb-default/logs" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(logFrame(1, "INFO out\n"))
+		w.Write(logFrame(2, "WARNING err\n"))
+	}))
+
+	logs, err := client.ContainerLogs(context.Background(), "mindsdb-default", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logs.Close()
+	got, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "INFO out\nWARNING err\n" {
+		t.Errorf("logs = %q", got)
+	}
+}
+
+func TestSplitImageRef(t *testing.T) {
+	tests := []struct{ ref, image, tag string }{
+		{"mindsdb/mindsdb", "mindsdb/mindsdb", "latest"},
+		{"mindsdb/mindsdb:v25.1.0", "mindsdb/mindsdb", "v25.1.0"},
+		{"localhost:5000/mindsdb", "localhost:5000/mindsdb", "latest"},
+		{"localhost:5000/mindsdb:dev", "localhost:5000/mindsdb", "dev"},
+		{"mindsdb/mindsdb@sha256:abc123", "mindsdb/mindsdb", "sha256:abc123"},
+	}
+	for _, tt := range tests {
+		image, tag := splitImageRef(tt.ref)
+		if image != tt.image || tag != tt.tag {
+			t.Errorf("splitImageRef(%q) = %q, %q; want %q, %q", tt.ref, image, tag, tt.image, tt.tag)
+		}
+	}
+}
+
+func TestDockerRuntimeVolumeExists(t *testing.T) {
+	client := newFakeDocker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/volumes/mindsdb-cli-data" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"get missing: no such volume"}`)
+			return
+		}
+		io.WriteString(w, `{"Name":"mindsdb-cli-data"}`)
+	}))
+	rt := &dockerRuntime{docker: client}
+
+	for volume, want := range map[string]bool{"mindsdb-cli-data": true, "missing": false} {
+		if got, err := rt.VolumeExists(context.Background(), volume); err != nil || got != want {
+			t.Errorf("VolumeExists(%q) = %v, %v; want %v", volume, got, err, want)
+		}
+	}
+}
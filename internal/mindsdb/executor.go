@@ -0,0 +1,28 @@
+package mindsdb
+
+import "context"
+
+// Rows is the result set returned by Query, regardless of the wire protocol
+// used to talk to MindsDB. *sql.Rows satisfies it directly.
+type Rows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+	Close() error
+}
+
+// Executor is a connection to MindsDB over a specific wire protocol.
+// Every transport (PostgreSQL, MySQL, ...) implements it so that callers
+// never need to know which protocol the instance speaks. Cancelling the
+// context aborts the statement in flight.
+type Executor interface {
+	// Query runs a statement and returns its result set
+	Query(ctx context.Context, query string) (Rows, error)
+	// Exec runs a statement that does not return rows
+	Exec(ctx context.Context, query string) error
+	// Ping verifies the connection is still alive
+	Ping(ctx context.Context) error
+	// Close releases the underlying connection
+	Close() error
+}
@@ -0,0 +1,107 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeExecutor is an in-memory Executor. Each statement is answered by
+// respond and recorded, so client logic can be tested without a server.
+type fakeExecutor struct {
+	respond    func(query string) (*memoryRows, error)
+	statements []string
+	closed     bool
+}
+
+var _ Executor = (*fakeExecutor)(nil)
+
+func (f *fakeExecutor) Query(ctx context.Context, query string) (Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.statements = append(f.statements, query)
+	if f.respond == nil {
+		return &memoryRows{}, nil
+	}
+	rows, err := f.respond(query)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (f *fakeExecutor) Exec(ctx context.Context, query string) error {
+	rows, err := f.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func (f *fakeExecutor) Ping(ctx context.Context) error {
+	return f.Exec(ctx, "SELECT 1")
+}
+
+func (f *fakeExecutor) Close() error {
+	f.closed = true
+	return nil
+}
+
+// table builds an in-memory result set
+func table(columns []string, rows ...[]interface{}) *memoryRows {
+	return &memoryRows{columns: columns, data: rows}
+}
+
+func TestClientRunsStatementsThroughExecutor(t *testing.T) {
+	fake := &fakeExecutor{respond: func(query string) (*memoryRows, error) {
+		if query == "SELECT * FROM missing" {
+			return nil, errors.New("Table 'missing' not found")
+		}
+		return table([]string{"name"}, []interface{}{"home_rentals"}, []interface{}{nil}), nil
+	}}
+	client := &MindsDBClient{Conn: fake}
+	ctx := context.Background()
+
+	rows, err := client.Query(ctx, "SELECT name FROM models")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name interface{}
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, asString(name))
+	}
+	if strings.Join(names, ",") != "home_rentals," {
+		t.Errorf("names = %q", names)
+	}
+
+	if err := client.Exec(ctx, "DROP MODEL x"); err != nil {
+		t.Errorf("Exec() error = %v", err)
+	}
+	if err := client.Ping(ctx); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+	if _, err := client.Query(ctx, "SELECT * FROM missing"); err == nil || err.Error() != "Table 'missing' not found" {
+		t.Errorf("Query() error = %v, want the executor's error", err)
+	}
+
+	want := "SELECT name FROM models|DROP MODEL x|SELECT 1|SELECT * FROM missing"
+	if got := strings.Join(fake.statements, "|"); got != want {
+		t.Errorf("statements = %s, want %s", got, want)
+	}
+}
+
+func TestClientWithoutConnection(t *testing.T) {
+	client := &MindsDBClient{}
+	if _, err := client.Query(context.Background(), "SELECT 1"); err == nil {
+		t.Error("Query() without a connection succeeded")
+	}
+	if err := client.Exec(context.Background(), "SELECT 1"); err == nil {
+		t.Error("Exec() without a connection succeeded")
+	}
+}
@@ -0,0 +1,246 @@
+package mindsdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+)
+
+// httpExecutor runs queries through MindsDB's HTTP SQL API (port 47334).
+// It is useful behind proxies that only allow HTTP traffic.
+type httpExecutor struct {
+	baseURL string
+	client  *http.Client
+	context map[string]interface{}
+}
+
+// sqlQueryRequest is the body of POST /api/sql/query
+type sqlQueryRequest struct {
+	Query   string                 `json:"query"`
+	Context map[string]interface{} `json:"context"`
+}
+
+// sqlQueryResponse is the body returned by POST /api/sql/query
+type sqlQueryResponse struct {
+	Type         string                 `json:"type"`
+	ColumnNames  []string               `json:"column_names"`
+	Data         [][]interface{}        `json:"data"`
+	ErrorCode    interface{}            `json:"error_code"`
+	ErrorMessage string                 `json:"error_message"`
+	Context      map[string]interface{} `json:"context"`
+}
+
+// newHTTPExecutor connects to the MindsDB HTTP API. host may be a bare
+// host:port or a full http(s) URL; bare hosts use https when tlsConfig is
+// set. When a user is given the executor logs in first so the session cookie
+// is sent with every query.
+func newHTTPExecutor(ctx context.Context, host, user, pass, database string, tlsConfig *tls.Config) (*httpExecutor, error) {
+	baseURL := strings.TrimRight(host, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		if tlsConfig != nil {
+			baseURL = "https://" + baseURL
+		} else {
+			baseURL = "http://" + baseURL
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &httpExecutor{
+		baseURL: baseURL,
+		client:  &http.Client{Jar: jar, Transport: transport},
+		context: map[string]interface{}{"db": database},
+	}
+
+	if user != "" {
+		if err := e.login(ctx, user, pass); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.Ping(ctx); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// post sends a JSON body to an API path
+func (e *httpExecutor) post(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return e.client.Do(req)
+}
+
+// login authenticates against /api/login, storing the session cookie
+func (e *httpExecutor) login(ctx context.Context, user, pass string) error {
+	resp, err := e.post(ctx, "/api/login", map[string]string{"username": user, "password": pass})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("login failed: %s", httpErrorMessage(resp))
+	}
+	return nil
+}
+
+// run posts a statement to the SQL endpoint and decodes the result
+func (e *httpExecutor) run(ctx context.Context, query string) (*sqlQueryResponse, error) {
+	resp, err := e.post(ctx, "/api/sql/query", sqlQueryRequest{Query: query, Context: e.context})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP API error: %s", httpErrorMessage(resp))
+	}
+
+	var result sqlQueryResponse
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode HTTP API response: %w", err)
+	}
+
+	if result.Type == "error" {
+		return nil, fmt.Errorf("%s", result.ErrorMessage)
+	}
+
+	// Keep statements such as USE <db> effective for the next query
+	if result.Context != nil {
+		e.context = result.Context
+	}
+	return &result, nil
+}
+
+func (e *httpExecutor) Query(ctx context.Context, query string) (Rows, error) {
+	result, err := e.run(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if result.Type != "table" {
+		return &memoryRows{}, nil
+	}
+	return &memoryRows{columns: result.ColumnNames, data: result.Data}, nil
+}
+
+func (e *httpExecutor) Exec(ctx context.Context, query string) error {
+	_, err := e.run(ctx, query)
+	return err
+}
+
+func (e *httpExecutor) Ping(ctx context.Context) error {
+	_, err := e.run(ctx, "SELECT 1")
+	return err
+}
+
+func (e *httpExecutor) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// httpErrorMessage extracts a readable message from a failed HTTP response
+func httpErrorMessage(resp *http.Response) string {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var payload struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if json.Unmarshal(data, &payload) == nil {
+		if payload.Message != "" {
+			return fmt.Sprintf("%s (%s)", payload.Message, resp.Status)
+		}
+		if payload.Detail != "" {
+			return fmt.Sprintf("%s (%s)", payload.Detail, resp.Status)
+		}
+	}
+
+	if text := strings.TrimSpace(string(data)); text != "" {
+		return fmt.Sprintf("%s (%s)", text, resp.Status)
+	}
+	return resp.Status
+}
+
+// memoryRows is an in-memory result set implementing Rows
+type memoryRows struct {
+	columns []string
+	data    [][]interface{}
+	pos     int
+}
+
+func (r *memoryRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *memoryRows) Next() bool {
+	if r.pos >= len(r.data) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+func (r *memoryRows) Scan(dest ...interface{}) error {
+	if r.pos == 0 || r.pos > len(r.data) {
+		return fmt.Errorf("Scan called without a current row")
+	}
+	row := r.data[r.pos-1]
+	if len(dest) != len(row) {
+		return fmt.Errorf("expected %d destination arguments in Scan, not %d", len(row), len(dest))
+	}
+
+	for i, value := range row {
+		switch d := dest[i].(type) {
+		case *interface{}:
+			*d = value
+		case *string:
+			if value == nil {
+				*d = ""
+			} else {
+				*d = fmt.Sprintf("%v", value)
+			}
+		case *sql.NullString:
+			d.Valid = value != nil
+			d.String = ""
+			if value != nil {
+				d.String = fmt.Sprintf("%v", value)
+			}
+		default:
+			return fmt.Errorf("unsupported Scan destination %T for column %q", dest[i], r.columns[i])
+		}
+	}
+	return nil
+}
+
+func (r *memoryRows) Err() error {
+	return nil
+}
+
+func (r *memoryRows) Close() error {
+	r.pos = len(r.data)
+	return nil
+}
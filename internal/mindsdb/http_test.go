@@ -0,0 +1,234 @@
+package mindsdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSQLAPI is a stand-in for MindsDB's HTTP API. It answers a few fixed
+// statements and, when user is set, requires a login first.
+type fakeSQLAPI struct {
+	user, pass string
+	databases  []string // Context database of each query, in order
+}
+
+func (f *fakeSQLAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/login":
+		var creds map[string]string
+		json.NewDecoder(r.Body).Decode(&creds)
+		if creds["username"] != f.user || creds["password"] != f.pass {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"message":"Invalid username or password"}`)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "ok", Path: "/"})
+	case "/api/sql/query":
+		if cookie, err := r.Cookie("session"); f.user != "" && (err != nil || cookie.Value != "ok") {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `{"detail":"Not authenticated"}`)
+			return
+		}
+		var req sqlQueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		db, _ := req.Context["db"].(string)
+		f.databases = append(f.databases, db)
+
+		switch req.Query {
+		case "SELECT 1":
+			io.WriteString(w, `{"type":"table","column_names":["1"],"data":[[1]],"context":{"db":"`+db+`"}}`)
+		case "SELECT name, accuracy, engine FROM models":
+			io.WriteString(w, `{"type":"table","column_names":["name","accuracy","engine"],`+
+				`"data":[["home_rentals",0.98,"lightwood"],["forecast",12345678901234567890,null]],"context":{"db":"`+db+`"}}`)
+		case "USE sales":
+			io.WriteString(w, `{"type":"ok","context":{"db":"sales"}}`)
+		case "CREATE DATABASE x":
+			io.WriteString(w, `{"type":"ok","context":{"db":"`+db+`"}}`)
+		case "SELECT * FROM missing":
+			io.WriteString(w, `{"type":"error","error_code":0,"error_message":"Table 'missing' not found"}`)
+		case "SELECT garbage":
+			io.WriteString(w, `{"type":`)
+		case "SELECT slow":
+			// Answers only once the client gives up
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			io.WriteString(w, "upstream unavailable")
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeHTTPExecutor(t *testing.T, api *fakeSQLAPI, user, pass string) *httpExecutor {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	e, err := newHTTPExecutor(context.Background(), server.URL, user, pass, "mindsdb", nil)
+	if err != nil {
+		t.Fatalf("newHTTPExecutor() error = %v", err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestHTTPExecutorQuery(t *testing.T) {
+	e := newFakeHTTPExecutor(t, &fakeSQLAPI{}, "", "")
+
+	rows, err := e.Query(context.Background(), "SELECT name, accuracy, engine FROM models")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(columns, ",") != "name,accuracy,engine" {
+		t.Errorf("Columns() = %v", columns)
+	}
+
+	// Numbers are kept as written, without a detour through float64
+	want := [][]string{
+		{"home_rentals", "0.98", "lightwood"},
+		{"forecast", "12345678901234567890", "NULL"},
+	}
+	var got [][]string
+	for rows.Next() {
+		var name string
+		var accuracy interface{}
+		var engine sql.NullString
+		if err := rows.Scan(&name, &accuracy, &engine); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if _, ok := accuracy.(json.Number); !ok {
+			t.Errorf("accuracy scanned as %T, want json.Number", accuracy)
+		}
+		if !engine.Valid {
+			engine.String = "NULL"
+		}
+		got = append(got, []string{name, asString(accuracy), engine.String})
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if strings.Join(got[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHTTPExecutorStatementWithoutRows(t *testing.T) {
+	e := newFakeHTTPExecutor(t, &fakeSQLAPI{}, "", "")
+
+	if err := e.Exec(context.Background(), "CREATE DATABASE x"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	rows, err := e.Query(context.Background(), "CREATE DATABASE x")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if rows.Next() {
+		t.Error("Next() = true for a statement without rows")
+	}
+}
+
+func TestHTTPExecutorErrors(t *testing.T) {
+	e := newFakeHTTPExecutor(t, &fakeSQLAPI{}, "", "")
+
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{"SELECT * FROM missing", "Table 'missing' not found"},
+		{"SELECT garbage", "failed to decode HTTP API response"},
+		{"SELECT unknown", "HTTP API error: upstream unavailable (502 Bad Gateway)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := e.Query(context.Background(), tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Query() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHTTPExecutorUsesContextDeadline(t *testing.T) {
+	e := newFakeHTTPExecutor(t, &fakeSQLAPI{}, "", "")
+	if e.client.Timeout != 0 {
+		t.Errorf("client timeout = %s, want none so that --timeout 0 has no limit", e.client.Timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := e.Query(ctx, "SELECT slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Query() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestHTTPExecutorKeepsContext(t *testing.T) {
+	api := &fakeSQLAPI{}
+	e := newFakeHTTPExecutor(t, api, "", "")
+
+	if err := e.Exec(context.Background(), "USE sales"); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ping while connecting, USE, then the ping after it
+	want := "mindsdb,mindsdb,sales"
+	if got := strings.Join(api.databases, ","); got != want {
+		t.Errorf("context databases = %s, want %s", got, want)
+	}
+}
+
+func TestHTTPExecutorLogin(t *testing.T) {
+	api := &fakeSQLAPI{user: "admin", pass: "secret"}
+	e := newFakeHTTPExecutor(t, api, "admin", "secret")
+	if err := e.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() after login error = %v", err)
+	}
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+	_, err := newHTTPExecutor(context.Background(), server.URL, "admin", "wrong", "mindsdb", nil)
+	if err == nil || !strings.Contains(err.Error(), "login failed: Invalid username or password (401 Unauthorized)") {
+		t.Errorf("newHTTPExecutor() with a wrong password error = %v", err)
+	}
+
+	_, err = newHTTPExecutor(context.Background(), server.URL, "", "", "mindsdb", nil)
+	if err == nil || !strings.Contains(err.Error(), "Not authenticated (403 Forbidden)") {
+		t.Errorf("newHTTPExecutor() without login error = %v", err)
+	}
+}
+
+func TestHTTPExecutorBareHost(t *testing.T) {
+	server := httptest.NewServer(&fakeSQLAPI{})
+	defer server.Close()
+
+	e, err := newHTTPExecutor(context.Background(), strings.TrimPrefix(server.URL, "http://"), "", "", "mindsdb", nil)
+	if err != nil {
+		t.Fatalf("newHTTPExecutor() error = %v", err)
+	}
+	defer e.Close()
+	if e.baseURL != server.URL {
+		t.Errorf("baseURL = %q, want %q", e.baseURL, server.URL)
+	}
+}
@@ -0,0 +1,392 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MindsDBRepository is the image repository of the embedded instance
+	MindsDBRepository = "mindsdb/mindsdb"
+	// DefaultVersion is the image tag used when no version is given
+	DefaultVersion = "latest"
+)
+
+// Labels recording which image an embedded container was created from
+const (
+	ImageLabel               = "mindsdb-cli.image"                 // Requested reference, e.g. mindsdb/mindsdb:v25.1.2
+	DigestLabel              = "mindsdb-cli.image-digest"          // Digest the reference resolved to
+	PreviousImageLabel       = "mindsdb-cli.previous-image"        // Reference before the last upgrade
+	PreviousImageDigestLabel = "mindsdb-cli.previous-image-digest" // Digest before the last upgrade
+)
+
+var versionPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+// ValidateVersion checks that version is a valid image tag
+func ValidateVersion(version string) error {
+	if version != "" && !versionPattern.MatchString(version) {
+		return fmt.Errorf("invalid MindsDB version %q (expected an image tag such as v25.1.2 or latest)", version)
+	}
+	return nil
+}
+
+// ImageRef returns the MindsDB image reference for a version tag
+func ImageRef(version string) string {
+	if version == "" {
+		version = DefaultVersion
+	}
+	return MindsDBRepository + ":" + version
+}
+
+// embeddedImage is the image an instance's container is created from
+type embeddedImage struct {
+	Ref    string // Requested reference, e.g. mindsdb/mindsdb:latest
+	Digest string // Repository digest or, failing that, image ID
+}
+
+// pinned returns the reference to create containers from, so that the
+// container runs exactly the recorded image even if the tag moves
+func (i embeddedImage) pinned() string {
+	if i.Digest != "" {
+		return i.Digest
+	}
+	return i.Ref
+}
+
+// imageFromContainer returns the image a container was created from. For
+// containers created before the image labels existed it falls back to the
+// container's image reference and ID.
+func imageFromContainer(info *ContainerInfo) embeddedImage {
+	image := embeddedImage{Ref: info.Labels[ImageLabel], Digest: info.Labels[DigestLabel]}
+	if image.Ref == "" {
+		image.Ref = info.Image
+	}
+	if image.Digest == "" {
+		image.Digest = info.ImageID
+	}
+	return image
+}
+
+// previousImage returns the image a container ran before its last upgrade,
+// if any
+func previousImage(info *ContainerInfo) (embeddedImage, bool) {
+	image := embeddedImage{Ref: info.Labels[PreviousImageLabel], Digest: info.Labels[PreviousImageDigestLabel]}
+	return image, image.Ref != ""
+}
+
+// ContainerImage returns the image reference and digest a container was
+// created from
+func ContainerImage(info *ContainerInfo) (ref, digest string) {
+	image := imageFromContainer(info)
+	return image.Ref, image.Digest
+}
+
+// imageDigest picks the repository digest of ref from a local image,
+// falling back to the image ID
+func imageDigest(ref string, info *ImageInfo) string {
+	repo, _ := splitImageRef(ref)
+	for _, digest := range info.RepoDigests {
+		name, _, _ := strings.Cut(digest, "@")
+		if name == repo || strings.HasSuffix(name, "/"+repo) {
+			return digest
+		}
+	}
+	if len(info.RepoDigests) > 0 {
+		return info.RepoDigests[0]
+	}
+	return info.ID
+}
+
+// pullImage pulls ref and returns it along with the digest it resolved to
+func (c *MindsDBClient) pullImage(ctx context.Context, ref string) (embeddedImage, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return embeddedImage{}, err
+	}
+
+	fmt.Printf("📥 Pulling %s with %s...\n", ref, rt.Name())
+	if err := rt.PullImage(ctx, ref); err != nil {
+		return embeddedImage{}, fmt.Errorf("failed to pull MindsDB image: %w", err)
+	}
+	info, err := rt.InspectImage(ctx, ref)
+	if err != nil {
+		return embeddedImage{}, fmt.Errorf("failed to inspect image %s: %w", ref, err)
+	}
+	return embeddedImage{Ref: ref, Digest: imageDigest(ref, info)}, nil
+}
+
+// resolveImage returns ref and its digest, pulling it only when it is not
+// present locally or when pull is set
+func (c *MindsDBClient) resolveImage(ctx context.Context, ref string, pull bool) (embeddedImage, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return embeddedImage{}, err
+	}
+
+	if !pull {
+		info, err := rt.InspectImage(ctx, ref)
+		if err == nil {
+			fmt.Printf("📦 Using local image %s\n", ref)
+			return embeddedImage{Ref: ref, Digest: imageDigest(ref, info)}, nil
+		}
+		if !errors.Is(err, ErrImageNotFound) {
+			return embeddedImage{}, fmt.Errorf("failed to inspect image %s: %w", ref, err)
+		}
+	}
+	return c.pullImage(ctx, ref)
+}
+
+// normalizeImageRef strips the default registry from a reference, so that
+// docker.io/mindsdb/mindsdb:latest and mindsdb/mindsdb:latest compare equal
+func normalizeImageRef(ref string) string {
+	return strings.TrimPrefix(ref, "docker.io/")
+}
+
+// loadImageArchive loads the images in file and returns the MindsDB image
+// reference to use: ImageRef(version) when a version is given, otherwise
+// the MindsDB image found in the archive
+func (c *MindsDBClient) loadImageArchive(ctx context.Context, file, version string) (string, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fmt.Printf("📦 Loading image archive %s with %s...\n", file, rt.Name())
+	loaded, err := rt.LoadImage(ctx, f)
+	if err != nil {
+		return "", fmt.Errorf("failed to load image archive: %w", err)
+	}
+
+	for _, ref := range loaded {
+		ref = normalizeImageRef(ref)
+		repo, _ := splitImageRef(ref)
+		if (version == "" && repo == MindsDBRepository) || ref == ImageRef(version) {
+			fmt.Printf("✅ Loaded %s\n", ref)
+			return ref, nil
+		}
+	}
+	if version == "" && len(loaded) == 1 {
+		// An untagged archive only reports an image ID
+		return loaded[0], nil
+	}
+	return "", fmt.Errorf("%s does not contain %s (found: %s)", file, ImageRef(version), strings.Join(loaded, ", "))
+}
+
+// SaveImage writes the MindsDB image for version to w as a tar archive that
+// 'start --image-archive' can load, pulling the image first if needed
+func (c *MindsDBClient) SaveImage(ctx context.Context, version string, w io.Writer) (string, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+
+	ref := ImageRef(version)
+	if _, err := c.resolveImage(ctx, ref, false); err != nil {
+		return "", err
+	}
+	if err := rt.SaveImage(ctx, ref, w); err != nil {
+		return "", fmt.Errorf("failed to save image %s: %w", ref, err)
+	}
+	return ref, nil
+}
+
+// ensureImage makes sure a recorded image is available locally, pulling it
+// by digest if it was removed
+func (c *MindsDBClient) ensureImage(ctx context.Context, image embeddedImage) error {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+
+	_, err = rt.InspectImage(ctx, image.pinned())
+	if !errors.Is(err, ErrImageNotFound) {
+		return err
+	}
+	if !strings.Contains(image.pinned(), "@") {
+		return fmt.Errorf("image %s is no longer available locally and cannot be pulled by digest", image.pinned())
+	}
+	fmt.Printf("📥 Pulling %s with %s...\n", image.pinned(), rt.Name())
+	if err := rt.PullImage(ctx, image.pinned()); err != nil {
+		return fmt.Errorf("failed to pull %s: %w", image.pinned(), err)
+	}
+	return nil
+}
+
+// UpgradeResult describes an upgrade or rollback
+type UpgradeResult struct {
+	From, To       string // Image references
+	FromDigest     string
+	ToDigest       string
+	AlreadyCurrent bool // The instance already ran the requested image
+}
+
+// UpgradeEmbeddedMindsDB pulls the requested version and recreates the
+// instance's container from it on the same data volume and ports. The
+// previous image is recorded so RollbackEmbeddedMindsDB can return to it.
+func (c *MindsDBClient) UpgradeEmbeddedMindsDB(ctx context.Context, opts EmbeddedOptions) (*UpgradeResult, error) {
+	existing, err := c.existingWithVolume("upgrade")
+	if err != nil {
+		return nil, err
+	}
+	current := imageFromContainer(existing)
+
+	target, err := c.pullImage(ctx, ImageRef(opts.Version))
+	if err != nil {
+		return nil, err
+	}
+	result := &UpgradeResult{From: current.Ref, FromDigest: current.Digest, To: target.Ref, ToDigest: target.Digest}
+	if target.Digest == current.Digest {
+		result.AlreadyCurrent = true
+		return result, nil
+	}
+
+	labels := map[string]string{
+		PreviousImageLabel:       current.Ref,
+		PreviousImageDigestLabel: current.Digest,
+	}
+	if err := c.recreate(ctx, existing, opts, target, labels); err != nil {
+		// If the new container could not even be created, put the old one
+		// back so the instance does not disappear
+		if container, _ := c.inspectExisting(); container == nil {
+			fmt.Println("⏪ Recreating the previous container...")
+			opts.Ports = containerPorts(existing)
+			opts.Container = containerOptions(existing)
+			restoreErr := c.keepAuth(existing, &opts)
+			if restoreErr == nil {
+				_, restoreErr = c.createEmbeddedContainer(ctx, opts, current, nil)
+			}
+			if restoreErr != nil {
+				return result, fmt.Errorf("%w (recreating the previous container also failed: %v)", err, restoreErr)
+			}
+			return result, fmt.Errorf("%w (the previous container was recreated; start it with 'mindsdb-cli start%s')", err, InstanceArg(c.Instance))
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// RollbackEmbeddedMindsDB recreates the instance's container from the
+// image it ran before the last upgrade
+func (c *MindsDBClient) RollbackEmbeddedMindsDB(ctx context.Context, opts EmbeddedOptions) (*UpgradeResult, error) {
+	existing, err := c.existingWithVolume("roll back")
+	if err != nil {
+		return nil, err
+	}
+	current := imageFromContainer(existing)
+	previous, ok := previousImage(existing)
+	if !ok {
+		return nil, fmt.Errorf("container %s has not been upgraded, there is nothing to roll back to", existing.Name)
+	}
+	if err := c.ensureImage(ctx, previous); err != nil {
+		return nil, err
+	}
+
+	// Rolling back swaps the images, so a second rollback undoes the first
+	labels := map[string]string{
+		PreviousImageLabel:       current.Ref,
+		PreviousImageDigestLabel: current.Digest,
+	}
+	result := &UpgradeResult{From: current.Ref, FromDigest: current.Digest, To: previous.Ref, ToDigest: previous.Digest}
+	if err := c.recreate(ctx, existing, opts, previous, labels); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// existingWithVolume returns the instance's container, refusing containers
+// whose data would be lost by recreating them
+func (c *MindsDBClient) existingWithVolume(action string) (*ContainerInfo, error) {
+	existing, err := c.inspectExisting()
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("MindsDB container %s not found (use 'mindsdb-cli start%s' first)", c.containerName(), InstanceArg(c.Instance))
+	}
+	if !c.HasDataVolume(existing) {
+		return nil, fmt.Errorf("cannot %s %s: it has no data volume, so recreating it would lose all data "+
+			"(move the data with 'mindsdb-cli backup%s' and 'mindsdb-cli restore%s' first)",
+			action, existing.Name, InstanceArg(c.Instance), InstanceArg(c.Instance))
+	}
+	return existing, nil
+}
+
+// recreate replaces the instance's container with one created from image,
+// keeping its data volume and host ports, then waits until it is ready.
+// The container options and authentication are kept too unless
+// opts.Recreate and opts.Auth ask to replace them.
+func (c *MindsDBClient) recreate(ctx context.Context, existing *ContainerInfo, opts EmbeddedOptions, image embeddedImage, labels map[string]string) error {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+
+	opts.Ports = containerPorts(existing)
+	opts.AutoPort = false
+	if !opts.Recreate {
+		opts.Container = containerOptions(existing)
+	}
+	if !opts.Auth {
+		if err := c.keepAuth(existing, &opts); err != nil {
+			return err
+		}
+	}
+
+	// A stopped container's ports may have been taken since; check them
+	// before removing it rather than failing to create its replacement
+	if !existing.Running {
+		if err := checkPortsFree(opts.Ports); err != nil {
+			return fmt.Errorf("cannot replace container %s: %w", existing.Name, err)
+		}
+	}
+
+	fmt.Println("🛑 Replacing the MindsDB container (data volume is kept)...")
+	if err := rt.StopContainer(ctx, existing.ID, nil); err != nil {
+		return fmt.Errorf("failed to stop container: %w", err)
+	}
+	if err := rt.RemoveContainer(ctx, existing.ID); err != nil {
+		return fmt.Errorf("failed to remove container: %w", err)
+	}
+
+	containerID, err := c.createEmbeddedContainer(ctx, opts, image, labels)
+	if err != nil {
+		return err
+	}
+	if err := rt.StartContainer(ctx, containerID); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	c.ContainerID = containerID
+	return c.waitForMindsDB(containerID, opts)
+}
+
+// recreateWithOptions replaces the instance's container with one using the
+// requested options, keeping its image, upgrade history, ports and data
+func (c *MindsDBClient) recreateWithOptions(ctx context.Context, existing *ContainerInfo, opts EmbeddedOptions) error {
+	if !c.HasDataVolume(existing) {
+		return fmt.Errorf("cannot recreate %s: it has no data volume, so recreating it would lose all data", existing.Name)
+	}
+	image := imageFromContainer(existing)
+	if err := c.ensureImage(ctx, image); err != nil {
+		return err
+	}
+
+	var labels map[string]string
+	if previous, ok := previousImage(existing); ok {
+		labels = map[string]string{
+			PreviousImageLabel:       previous.Ref,
+			PreviousImageDigestLabel: previous.Digest,
+		}
+	}
+	return c.recreate(ctx, existing, opts, image, labels)
+}
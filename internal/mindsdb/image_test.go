@@ -0,0 +1,157 @@
+package mindsdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateVersion(t *testing.T) {
+	valid := []string{"", "latest", "v25.1.2", "25.1.2", "v25.1.2-rc1", "cpu_only", strings.Repeat("a", 128)}
+	for _, version := range valid {
+		if err := ValidateVersion(version); err != nil {
+			t.Errorf("ValidateVersion(%q) error = %v", version, err)
+		}
+	}
+
+	invalid := []string{
+		"-latest", // Tags cannot start with a separator
+		".v1",
+		"v25.1.2 ",  // Whitespace
+		"v1:latest", // A reference, not a tag
+		"mindsdb/mindsdb:v1",
+		"v1@sha256:abc",
+		"v1;rm -rf /",
+		strings.Repeat("a", 129), // Too long
+	}
+	for _, version := range invalid {
+		err := ValidateVersion(version)
+		if err == nil || !strings.Contains(err.Error(), "invalid MindsDB version") {
+			t.Errorf("ValidateVersion(%q) error = %v, want it rejected", version, err)
+		}
+	}
+}
+
+func TestImageRef(t *testing.T) {
+	if got := ImageRef(""); got != "mindsdb/mindsdb:latest" {
+		t.Errorf("ImageRef(\"\") = %q", got)
+	}
+	if got := ImageRef("v25.1.2"); got != "mindsdb/mindsdb:v25.1.2" {
+		t.Errorf("ImageRef(\"v25.1.2\") = %q", got)
+	}
+}
+
+func TestImageDigest(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		info ImageInfo
+		want string
+	}{
+		{
+			name: "docker",
+			ref:  "mindsdb/mindsdb:v25.1.2",
+			info: ImageInfo{ID: "sha256:1111", RepoDigests: []string{"mindsdb/mindsdb@sha256:aaaa"}},
+			want: "mindsdb/mindsdb@sha256:aaaa",
+		},
+		{
+			// Podman and nerdctl qualify names with the registry
+			name: "qualified digest",
+			ref:  "mindsdb/mindsdb:latest",
+			info: ImageInfo{ID: "sha256:1111", RepoDigests: []string{"docker.io/mindsdb/mindsdb@sha256:aaaa"}},
+			want: "docker.io/mindsdb/mindsdb@sha256:aaaa",
+		},
+		{
+			name: "digest of the requested repository",
+			ref:  "mindsdb/mindsdb:latest",
+			info: ImageInfo{ID: "sha256:1111", RepoDigests: []string{
+				"registry.example.com/mirror/other@sha256:bbbb",
+				"mindsdb/mindsdb@sha256:aaaa",
+			}},
+			want: "mindsdb/mindsdb@sha256:aaaa",
+		},
+		{
+			name: "other repository only",
+			ref:  "mindsdb/mindsdb:latest",
+			info: ImageInfo{ID: "sha256:1111", RepoDigests: []string{"registry.example.com/mindsdb-mirror@sha256:bbbb"}},
+			want: "registry.example.com/mindsdb-mirror@sha256:bbbb",
+		},
+		{
+			// Built or loaded images have no repository digest
+			name: "image ID",
+			ref:  "mindsdb/mindsdb:dev",
+			info: ImageInfo{ID: "sha256:1111"},
+			want: "sha256:1111",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageDigest(tt.ref, &tt.info); got != tt.want {
+				t.Errorf("imageDigest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageFromContainer(t *testing.T) {
+	labelled := &ContainerInfo{
+		Image:   "sha256:1111",
+		ImageID: "sha256:1111",
+		Labels:  map[string]string{ImageLabel: "mindsdb/mindsdb:v25.1.2", DigestLabel: "mindsdb/mindsdb@sha256:aaaa"},
+	}
+	if got := imageFromContainer(labelled); got.Ref != "mindsdb/mindsdb:v25.1.2" || got.pinned() != "mindsdb/mindsdb@sha256:aaaa" {
+		t.Errorf("imageFromContainer() = %+v", got)
+	}
+
+	// Containers created before the labels existed
+	old := &ContainerInfo{Image: "mindsdb/mindsdb:latest", ImageID: "sha256:1111"}
+	if got := imageFromContainer(old); got.Ref != "mindsdb/mindsdb:latest" || got.pinned() != "sha256:1111" {
+		t.Errorf("imageFromContainer() = %+v", got)
+	}
+
+	if got := (embeddedImage{Ref: "mindsdb/mindsdb:latest"}).pinned(); got != "mindsdb/mindsdb:latest" {
+		t.Errorf("pinned() without a digest = %q", got)
+	}
+}
+
+func TestLoadImageArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "mindsdb.tar")
+	if err := os.WriteFile(archive, []byte("tar"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		loaded  []string
+		version string
+		want    string
+		wantErr string
+	}{
+		{"mindsdb image", []string{"postgres:16", "mindsdb/mindsdb:v25.1.2"}, "", "mindsdb/mindsdb:v25.1.2", ""},
+		{"qualified by podman", []string{"docker.io/mindsdb/mindsdb:latest"}, "", "mindsdb/mindsdb:latest", ""},
+		{"requested version", []string{"mindsdb/mindsdb:v25.1.1", "mindsdb/mindsdb:v25.1.2"}, "v25.1.2", "mindsdb/mindsdb:v25.1.2", ""},
+		{"untagged archive", []string{"sha256:0123abcd"}, "", "sha256:0123abcd", ""},
+		{"version missing", []string{"mindsdb/mindsdb:v25.1.1"}, "v25.1.2", "", "does not contain mindsdb/mindsdb:v25.1.2 (found: mindsdb/mindsdb:v25.1.1)"},
+		{"other images only", []string{"postgres:16", "redis:7"}, "", "", "does not contain mindsdb/mindsdb:latest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newFakeRuntime()
+			rt.loaded = tt.loaded
+			client := &MindsDBClient{Runtime: rt}
+
+			got, err := client.loadImageArchive(context.Background(), archive, tt.version)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("loadImageArchive() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("loadImageArchive() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package mindsdb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const (
+	// DefaultInstance is the embedded instance used when --instance is not given
+	DefaultInstance = "default"
+	// InstanceLabel marks embedded containers with their instance name
+	InstanceLabel = "mindsdb-cli.instance"
+	// DataVolume is the named volume holding the default instance's data
+	DataVolume = "mindsdb-cli-data"
+	// StoragePath is where MindsDB keeps its data inside the container
+	StoragePath = "/root/mdb_storage"
+)
+
+var instanceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// ValidateInstanceName checks that name can be used in container and
+// volume names
+func ValidateInstanceName(name string) error {
+	if name == "" || name == DefaultInstance {
+		return nil
+	}
+	if !instanceNamePattern.MatchString(name) || len(name) > 40 {
+		return fmt.Errorf("invalid instance name %q (use lowercase letters, digits, '-', '_' and '.')", name)
+	}
+	return nil
+}
+
+// instanceName normalizes an empty name to DefaultInstance
+func instanceName(name string) string {
+	if name == "" {
+		return DefaultInstance
+	}
+	return name
+}
+
+// InstanceArg returns the --instance flag to repeat in hints, or nothing
+// for the default instance
+func InstanceArg(name string) string {
+	if instanceName(name) == DefaultInstance {
+		return ""
+	}
+	return " --instance " + name
+}
+
+// InstanceContainerName returns the container name of an embedded instance.
+// The default instance keeps the historical name mindsdb-cli-embedded.
+func InstanceContainerName(name string) string {
+	if instanceName(name) == DefaultInstance {
+		return ContainerName
+	}
+	return ContainerName + "-" + name
+}
+
+// InstanceVolumeName returns the data volume of an embedded instance
+func InstanceVolumeName(name string) string {
+	if instanceName(name) == DefaultInstance {
+		return DataVolume
+	}
+	return DataVolume + "-" + name
+}
+
+// Instance is an embedded MindsDB instance and its container
+type Instance struct {
+	Name      string
+	Container *ContainerInfo
+	Ports     Ports
+}
+
+// ListInstances returns the embedded instances known to the runtime, sorted
+// by name. A default container created before instances were labelled is
+// included too.
+func ListInstances(ctx context.Context, rt Runtime) ([]Instance, error) {
+	containers, err := rt.ListContainers(ctx, InstanceLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var instances []Instance
+	seen := map[string]bool{}
+	for _, container := range containers {
+		name := instanceName(container.Labels[InstanceLabel])
+		seen[name] = true
+		instances = append(instances, Instance{Name: name, Container: container, Ports: containerPorts(container)})
+	}
+
+	if !seen[DefaultInstance] {
+		c := &MindsDBClient{Runtime: rt}
+		container, err := c.inspectExisting()
+		if err != nil {
+			return nil, err
+		}
+		if container != nil {
+			instances = append(instances, Instance{Name: DefaultInstance, Container: container, Ports: containerPorts(container)})
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+	return instances, nil
+}
@@ -0,0 +1,56 @@
+package mindsdb
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateInstanceName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"", true},
+		{DefaultInstance, true},
+		{"scratch", true},
+		{"ci-2", true},
+		{"team_a.v2", true},
+		{"0day", true},
+		{strings.Repeat("a", 40), true},
+		{strings.Repeat("a", 41), false},
+		{"Scratch", false},
+		{"-scratch", false},
+		{".hidden", false},
+		{"my instance", false},
+		{"a/b", false},
+		{"a:b", false},
+		{"ünï", false},
+	}
+	for _, tt := range tests {
+		err := ValidateInstanceName(tt.name)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateInstanceName(%q) = %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestInstanceNames(t *testing.T) {
+	tests := []struct {
+		instance, container, volume, arg string
+	}{
+		{"", ContainerName, DataVolume, ""},
+		{DefaultInstance, ContainerName, DataVolume, ""},
+		{"scratch", ContainerName + "-scratch", DataVolume + "-scratch", " --instance scratch"},
+	}
+	for _, tt := range tests {
+		if got := InstanceContainerName(tt.instance); got != tt.container {
+			t.Errorf("InstanceContainerName(%q) = %q, want %q", tt.instance, got, tt.container)
+		}
+		if got := InstanceVolumeName(tt.instance); got != tt.volume {
+			t.Errorf("InstanceVolumeName(%q) = %q, want %q", tt.instance, got, tt.volume)
+		}
+		if got := InstanceArg(tt.instance); got != tt.arg {
+			t.Errorf("InstanceArg(%q) = %q, want %q", tt.instance, got, tt.arg)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package mindsdb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Log levels used by MindsDB, in increasing severity
+const (
+	LevelDebug = iota
+	LevelInfo
+	LevelWarning
+	LevelError
+	LevelCritical
+)
+
+var logLevels = map[string]int{
+	"DEBUG":    LevelDebug,
+	"INFO":     LevelInfo,
+	"WARN":     LevelWarning,
+	"WARNING":  LevelWarning,
+	"ERROR":    LevelError,
+	"CRITICAL": LevelCritical,
+	"FATAL":    LevelCritical,
+}
+
+// levelPattern finds the level of a MindsDB log line such as
+// "2024-06-19 09:02:31,469 http INFO     mindsdb.api.http: starting" or
+// "INFO:     127.0.0.1:50000 - GET /api/status"
+var levelPattern = regexp.MustCompile(`\b(DEBUG|INFO|WARNING|WARN|ERROR|CRITICAL|FATAL)\b`)
+
+// levelPrefixLen is how far into a line the level is looked for, so that
+// words like ERROR in a message body are not mistaken for the level
+const levelPrefixLen = 100
+
+// ParseLogLevel converts a level name such as "warning" to its severity
+func ParseLogLevel(name string) (int, error) {
+	level, ok := logLevels[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("invalid log level %q (use debug, info, warning, error or critical)", name)
+	}
+	return level, nil
+}
+
+// lineLevel returns the level of a log line, or false for lines without
+// one, such as the lines of a traceback
+func lineLevel(line string) (int, bool) {
+	prefix := line
+	if len(prefix) > levelPrefixLen {
+		prefix = prefix[:levelPrefixLen]
+	}
+	match := levelPattern.FindString(prefix)
+	if match == "" {
+		return 0, false
+	}
+	return logLevels[match], true
+}
+
+// LogFilter selects MindsDB log entries by level and pattern. An entry is a
+// line with a level together with the lines below it that have none
+// (tracebacks, multi-line messages); entries are shown or hidden as a whole.
+type LogFilter struct {
+	MinLevel int            // Lowest level to show
+	Grep     *regexp.Regexp // Only show entries with a line matching this, if set
+}
+
+// Copy writes the entries of r that pass the filter to w, until r is
+// exhausted. The lines of an entry are held back only until one of them
+// matches, so followed logs are shown as they arrive.
+func (f LogFilter) Copy(w io.Writer, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	level := LevelInfo // Lines before the first entry are treated as info
+	matched := f.Grep == nil
+	var pending []string // Lines of the current entry while it has not matched
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if l, ok := lineLevel(line); ok {
+			level = l
+			matched = f.Grep == nil
+			pending = pending[:0]
+		}
+		if level < f.MinLevel {
+			continue
+		}
+		pending = append(pending, line)
+		if !matched && !f.Grep.MatchString(line) {
+			continue
+		}
+		matched = true
+
+		for _, line := range pending {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		pending = pending[:0]
+	}
+	return scanner.Err()
+}
+
+// ContainerLogs returns the combined output of the instance's container
+func (c *MindsDBClient) ContainerLogs(ctx context.Context, opts LogsOptions) (io.ReadCloser, error) {
+	container, err := c.inspectExisting()
+	if err != nil {
+		return nil, err
+	}
+	if container == nil {
+		return nil, fmt.Errorf("MindsDB container %s not found", c.containerName())
+	}
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+	return rt.ContainerLogs(ctx, container.ID, opts)
+}
@@ -0,0 +1,169 @@
+package mindsdb
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+const sampleLogs = `Starting MindsDB
+2024-06-19 09:02:31,469 http INFO     mindsdb.api.http: starting
+2024-06-19 09:02:32,001 http DEBUG    mindsdb.api.http: config loaded
+2024-06-19 09:02:33,120 http WARNING  mindsdb.integrations: handler postgres has no driver
+2024-06-19 09:02:34,500 ml_task ERROR    mindsdb.ml: training failed
+Traceback (most recent call last):
+  File "lightwood/api.py", line 12, in learn
+ValueError: Column 'amount' not found
+INFO:     127.0.0.1:50000 - "GET /api/status HTTP/1.1" 200 OK
+2024-06-19 09:02:35,900 http CRITICAL mindsdb: shutting down
+`
+
+func TestLogFilterLevels(t *testing.T) {
+	tests := []struct {
+		level string
+		want  []string
+	}{
+		{"debug", []string{"Starting", "http: starting", "config loaded", "no driver", "training failed", "Traceback", "File", "ValueError", "GET /api/status", "shutting down"}},
+		{"info", []string{"Starting", "http: starting", "no driver", "training failed", "Traceback", "File", "ValueError", "GET /api/status", "shutting down"}},
+		{"warning", []string{"no driver", "training failed", "Traceback", "File", "ValueError", "shutting down"}},
+		// The traceback belongs to the ERROR line above it
+		{"error", []string{"training failed", "Traceback", "File", "ValueError", "shutting down"}},
+		{"critical", []string{"shutting down"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLogLevel(tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkLines(t, filterLogs(t, LogFilter{MinLevel: level}, strings.NewReader(sampleLogs)), tt.want)
+		})
+	}
+}
+
+func TestLogFilterGrepKeepsWholeEntries(t *testing.T) {
+	tests := []struct {
+		name  string
+		grep  string
+		level int
+		want  []string
+	}{
+		// A match in the traceback shows the line that started the entry
+		{"match in continuation line", "amount", LevelDebug, []string{"training failed", "Traceback", "File", "ValueError"}},
+		// A match in the first line shows the lines that follow it
+		{"match in first line", "training failed", LevelDebug, []string{"training failed", "Traceback", "File", "ValueError"}},
+		{"several entries", "mindsdb\\.api", LevelDebug, []string{"http: starting", "config loaded"}},
+		{"level applies first", "mindsdb\\.api", LevelInfo, []string{"http: starting"}},
+		{"no match", "nothing like this", LevelDebug, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := LogFilter{MinLevel: tt.level, Grep: regexp.MustCompile(tt.grep)}
+			checkLines(t, filterLogs(t, filter, strings.NewReader(sampleLogs)), tt.want)
+		})
+	}
+}
+
+func TestLogFilterLinesSpanningReads(t *testing.T) {
+	filter := LogFilter{MinLevel: LevelError, Grep: regexp.MustCompile("ValueError")}
+	want := filterLogs(t, filter, strings.NewReader(sampleLogs))
+
+	readers := map[string]io.Reader{
+		"one byte at a time":    iotest.OneByteReader(strings.NewReader(sampleLogs)),
+		"half reads":            iotest.HalfReader(strings.NewReader(sampleLogs)),
+		"without final newline": iotest.DataErrReader(strings.NewReader(strings.TrimSuffix(sampleLogs, "\n"))),
+	}
+	for name, r := range readers {
+		t.Run(name, func(t *testing.T) {
+			if got := filterLogs(t, filter, r); got != want {
+				t.Errorf("Copy() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestLogFilterStreamsMatchingEntries(t *testing.T) {
+	r, w := io.Pipe()
+	out, lines := io.Pipe()
+	filter := LogFilter{MinLevel: LevelInfo, Grep: regexp.MustCompile("amount")}
+	go func() {
+		filter.Copy(lines, r)
+		lines.Close()
+	}()
+
+	// The matching entry is shown as soon as its matching line arrives,
+	// before the log is closed
+	go io.WriteString(w, "2024-06-19 09:02:34,500 ml_task ERROR mindsdb.ml: training failed\nValueError: Column 'amount' not found\n")
+	scanner := bufio.NewScanner(out)
+	got := make(chan []string)
+	go func() {
+		var seen []string
+		for len(seen) < 2 && scanner.Scan() {
+			seen = append(seen, scanner.Text())
+		}
+		got <- seen
+	}()
+	select {
+	case seen := <-got:
+		checkLines(t, strings.Join(seen, "\n"), []string{"training failed", "ValueError"})
+	case <-time.After(5 * time.Second):
+		t.Fatal("the matching entry was not shown before the log ended")
+	}
+	w.Close()
+}
+
+func TestLineLevel(t *testing.T) {
+	tests := []struct {
+		line  string
+		level int
+		ok    bool
+	}{
+		{"2024-06-19 09:02:31,469 http INFO     mindsdb.api.http: starting", LevelInfo, true},
+		{"WARN: deprecated option", LevelWarning, true},
+		{"2024-06-19 FATAL mindsdb: out of memory", LevelCritical, true},
+		{"  File \"lightwood/api.py\", line 12", 0, false},
+		{"INFORMATION_SCHEMA ready", 0, false},
+		// A level far into the message is part of the text, not the level
+		{strings.Repeat("x", levelPrefixLen) + " ERROR", 0, false},
+	}
+	for _, tt := range tests {
+		level, ok := lineLevel(tt.line)
+		if level != tt.level || ok != tt.ok {
+			t.Errorf("lineLevel(%q) = %d, %v; want %d, %v", tt.line, level, ok, tt.level, tt.ok)
+		}
+	}
+	if _, err := ParseLogLevel("verbose"); err == nil {
+		t.Error("ParseLogLevel(\"verbose\") succeeded")
+	}
+}
+
+func filterLogs(t *testing.T, filter LogFilter, r io.Reader) string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := filter.Copy(&out, r); err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	return out.String()
+}
+
+// checkLines checks that output has one line per want, each containing it
+func checkLines(t *testing.T, output string, want []string) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if output == "" {
+		lines = nil
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), output)
+	}
+	for i := range want {
+		if !strings.Contains(lines[i], want[i]) {
+			t.Errorf("line %d = %q, want it to contain %q", i, lines[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package mindsdb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Model statuses reported by information_schema.models
+const (
+	ModelStatusGenerating = "generating"
+	ModelStatusTraining   = "training"
+	ModelStatusComplete   = "complete"
+	ModelStatusError      = "error"
+)
+
+// ModelStatuses lists the statuses a model can have
+var ModelStatuses = []string{ModelStatusGenerating, ModelStatusTraining, ModelStatusComplete, ModelStatusError}
+
+// ModelFilter restricts which models ListModelsQuery returns; empty fields
+// match everything
+type ModelFilter struct {
+	Project string
+	Status  string
+	Engine  string
+}
+
+// ListModelsQuery returns the statement listing the models of the catalog
+// that match filter, with the columns shown by 'list-models'
+func ListModelsQuery(filter ModelFilter) string {
+	var conditions []string
+	for _, condition := range []struct{ column, value string }{
+		{"project", filter.Project},
+		{"status", filter.Status},
+		{"engine", filter.Engine},
+	} {
+		if condition.value != "" {
+			conditions = append(conditions, condition.column+" = "+QuoteString(condition.value))
+		}
+	}
+
+	query := "SELECT project, name, engine, status, version, accuracy, predict, training_time FROM information_schema.models"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query + " ORDER BY project, name, version"
+}
+
+// Model is a row of information_schema.models
+type Model struct {
+	Project string
+	Name    string
+	Status  string
+}
+
+// BusyModels returns the models that are still generating or training.
+// Without an active connection it connects to the running embedded
+// container with the credentials stored by 'start'.
+func (c *MindsDBClient) BusyModels(ctx context.Context) ([]Model, error) {
+	conn := c.Conn
+	if conn == nil {
+		container, err := c.inspectExisting()
+		if err != nil {
+			return nil, err
+		}
+		if container == nil || !container.Running {
+			return nil, fmt.Errorf("MindsDB container %s is not running", c.containerName())
+		}
+		embedded, err := c.openEmbedded(ctx, container)
+		if err != nil {
+			return nil, err
+		}
+		defer embedded.Close()
+		conn = embedded
+	}
+
+	rows, err := conn.Query(ctx, "SELECT project, name, status FROM information_schema.models")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var busy []Model
+	for rows.Next() {
+		var project, name, status interface{}
+		if err := rows.Scan(&project, &name, &status); err != nil {
+			return nil, err
+		}
+		model := Model{Project: asString(project), Name: asString(name), Status: strings.ToLower(asString(status))}
+		if model.Status == ModelStatusGenerating || model.Status == ModelStatusTraining {
+			busy = append(busy, model)
+		}
+	}
+	return busy, rows.Err()
+}
+
+// asString converts a scanned column value to a string
+func asString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// CreateModelOptions describes a CREATE MODEL statement
+type CreateModelOptions struct {
+	Project string   // Project to create the model in; MindsDB's default when empty
+	Name    string   // Model name
+	From    string   // Integration, or integration.table to train on the whole table
+	Select  string   // Training query run against the From integration
+	Predict string   // Target column
+	Engine  string   // ML engine; MindsDB's default when empty
+	Using   []string // Additional key=value parameters
+	OrderBy string   // Time-series: column ordering the rows
+	GroupBy []string // Time-series: columns partitioning the rows
+	Window  int      // Time-series: number of rows to look back
+	Horizon int      // Time-series: number of rows to predict
+}
+
+// identifierPattern matches names that MindsDB accepts unquoted, such as
+// USING keys like encoders.location.module
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// Validate checks the options without connecting to MindsDB
+func (o CreateModelOptions) Validate() error {
+	switch {
+	case strings.TrimSpace(o.Name) == "":
+		return fmt.Errorf("a model name is required (--name)")
+	case strings.Contains(o.Name, "."):
+		return fmt.Errorf("invalid model name %q: use --project to choose the project", o.Name)
+	case strings.TrimSpace(o.Predict) == "":
+		return fmt.Errorf("a target column is required (--predict)")
+	case o.Select != "" && o.From == "":
+		return fmt.Errorf("--select needs --from to name the integration it runs against")
+	case o.From != "" && o.Select == "" && !strings.Contains(o.From, "."):
+		return fmt.Errorf("--from %s names no table: use --from %s.<table> or add --select", o.From, o.From)
+	case o.Window < 0 || o.Horizon < 0:
+		return fmt.Errorf("--window and --horizon must be positive")
+	case o.OrderBy == "" && (len(o.GroupBy) > 0 || o.Window > 0 || o.Horizon > 0):
+		return fmt.Errorf("--group-by, --window and --horizon are for time-series models and need --order-by")
+	}
+
+	for _, param := range o.Using {
+		key, _, ok := strings.Cut(param, "=")
+		if !ok || !identifierPattern.MatchString(strings.TrimSpace(key)) {
+			return fmt.Errorf("invalid --using value %q (expected key=value)", param)
+		}
+		if strings.EqualFold(strings.TrimSpace(key), "engine") {
+			return fmt.Errorf("use --engine instead of --using engine=...")
+		}
+	}
+	return nil
+}
+
+// Statement builds the CREATE MODEL statement, quoting every identifier
+func (o CreateModelOptions) Statement() (string, error) {
+	if err := o.Validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("CREATE MODEL ")
+	if o.Project != "" {
+		b.WriteString(QuoteIdentifier(o.Project) + ".")
+	}
+	b.WriteString(QuoteIdentifier(o.Name))
+
+	if o.From != "" {
+		integration, table, _ := strings.Cut(o.From, ".")
+		query := o.Select
+		if query == "" {
+			query = "SELECT * FROM " + quotePath(table)
+		}
+		fmt.Fprintf(&b, "\nFROM %s\n  (%s)", QuoteIdentifier(integration), strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	}
+	b.WriteString("\nPREDICT " + QuoteIdentifier(o.Predict))
+
+	if o.OrderBy != "" {
+		b.WriteString("\nORDER BY " + QuoteIdentifier(o.OrderBy))
+	}
+	if len(o.GroupBy) > 0 {
+		columns := make([]string, len(o.GroupBy))
+		for i, column := range o.GroupBy {
+			columns[i] = QuoteIdentifier(column)
+		}
+		b.WriteString("\nGROUP BY " + strings.Join(columns, ", "))
+	}
+	if o.Window > 0 {
+		fmt.Fprintf(&b, "\nWINDOW %d", o.Window)
+	}
+	if o.Horizon > 0 {
+		fmt.Fprintf(&b, "\nHORIZON %d", o.Horizon)
+	}
+
+	var params []string
+	if o.Engine != "" {
+		params = append(params, "engine = "+QuoteString(o.Engine))
+	}
+	for _, param := range o.Using {
+		key, value, _ := strings.Cut(param, "=")
+		params = append(params, strings.TrimSpace(key)+" = "+usingValue(strings.TrimSpace(value)))
+	}
+	if len(params) > 0 {
+		b.WriteString("\nUSING\n  " + strings.Join(params, ",\n  "))
+	}
+	return b.String(), nil
+}
+
+// quotePath quotes each part of a dotted name such as schema.table
+func quotePath(path string) string {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		parts[i] = QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// usingValue renders a USING value: numbers, booleans and JSON objects or
+// arrays are passed through, everything else becomes a string literal
+func usingValue(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	switch strings.ToLower(value) {
+	case "true", "false", "null":
+		return value
+	}
+	if (strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}")) ||
+		(strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]")) {
+		return value
+	}
+	return QuoteString(value)
+}
+
+// DefaultProject is the project MindsDB uses when none is given
+const DefaultProject = "mindsdb"
+
+// ModelState is the training state of a model's latest version
+type ModelState struct {
+	Project string
+	Name    string
+	Version string
+	Status  string
+	Error   string // Training error, set when Status is ModelStatusError
+}
+
+// ModelTrainingError is returned when training a model fails
+type ModelTrainingError struct {
+	Model   string
+	Message string
+}
+
+func (e *ModelTrainingError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("training model %s failed", e.Model)
+	}
+	return fmt.Sprintf("training model %s failed: %s", e.Model, e.Message)
+}
+
+// ModelStatus returns the state of the latest version of a model
+func (c *MindsDBClient) ModelStatus(ctx context.Context, project, name string) (*ModelState, error) {
+	if project == "" {
+		project = DefaultProject
+	}
+	query := fmt.Sprintf("SELECT version, status, error FROM information_schema.models WHERE project = %s AND name = %s ORDER BY version DESC LIMIT 1",
+		QuoteString(project), QuoteString(name))
+	rows, err := c.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("model %s.%s not found", project, name)
+	}
+	var version, status, message interface{}
+	if err := rows.Scan(&version, &status, &message); err != nil {
+		return nil, err
+	}
+	return &ModelState{
+		Project: project,
+		Name:    name,
+		Version: asString(version),
+		Status:  strings.ToLower(asString(status)),
+		Error:   asString(message),
+	}, rows.Err()
+}
+
+// WaitForModel polls a model every interval until its latest version is
+// complete or failed, calling onPoll with each state. A failed training
+// returns a *ModelTrainingError carrying MindsDB's error message.
+func (c *MindsDBClient) WaitForModel(ctx context.Context, project, name string, interval time.Duration, onPoll func(*ModelState)) (*ModelState, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		state, err := c.ModelStatus(ctx, project, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if onPoll != nil {
+			onPoll(state)
+		}
+
+		switch state.Status {
+		case ModelStatusComplete:
+			return state, nil
+		case ModelStatusError:
+			return state, &ModelTrainingError{Model: state.Project + "." + state.Name, Message: state.Error}
+		}
+
+		select {
+		case <-ctx.Done():
+			return state, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sections of a model description, each read with a DESCRIBE variant
+const (
+	DescribeInfo     = "info"     // The model's catalog row: status, accuracy, training options
+	DescribeFeatures = "features" // Column types, encoders and roles
+	DescribeModel    = "model"    // Candidate mixers with their accuracy
+	DescribeEnsemble = "ensemble" // How the mixers were combined
+)
+
+// DescribeSections lists the sections in display order
+var DescribeSections = []string{DescribeInfo, DescribeFeatures, DescribeModel, DescribeEnsemble}
+
+// DescribeModelQuery returns the DESCRIBE statement for one section of a
+// model's description. Only some engines, such as Lightwood, support the
+// sections other than info.
+func DescribeModelQuery(project, name, section string) string {
+	if project == "" {
+		project = DefaultProject
+	}
+	query := "DESCRIBE " + QuoteIdentifier(project) + "." + QuoteIdentifier(name)
+	if section != DescribeInfo {
+		query += "." + section
+	}
+	return query
+}
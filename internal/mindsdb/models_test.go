@@ -0,0 +1,249 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBusyModels(t *testing.T) {
+	fake := &fakeExecutor{respond: func(string) (*memoryRows, error) {
+		return table([]string{"project", "name", "status"},
+			[]interface{}{"mindsdb", "done", "complete"},
+			[]interface{}{"mindsdb", "fitting", "Training"},
+			[]interface{}{[]byte("sales"), "forecast", "generating"},
+			[]interface{}{"mindsdb", "broken", "error"},
+		), nil
+	}}
+	client := &MindsDBClient{Conn: fake}
+
+	busy, err := client.BusyModels(context.Background())
+	if err != nil {
+		t.Fatalf("BusyModels() error = %v", err)
+	}
+	want := []Model{
+		{Project: "mindsdb", Name: "fitting", Status: ModelStatusTraining},
+		{Project: "sales", Name: "forecast", Status: ModelStatusGenerating},
+	}
+	if len(busy) != len(want) {
+		t.Fatalf("BusyModels() = %+v, want %+v", busy, want)
+	}
+	for i := range want {
+		if busy[i] != want[i] {
+			t.Errorf("BusyModels()[%d] = %+v, want %+v", i, busy[i], want[i])
+		}
+	}
+}
+
+func TestListModelsQuery(t *testing.T) {
+	const base = "SELECT project, name, engine, status, version, accuracy, predict, training_time FROM information_schema.models"
+	if got := ListModelsQuery(ModelFilter{}); got != base+" ORDER BY project, name, version" {
+		t.Errorf("ListModelsQuery() = %s", got)
+	}
+	got := ListModelsQuery(ModelFilter{Project: "sales", Engine: "o'brien"})
+	if want := base + " WHERE project = 'sales' AND engine = 'o''brien' ORDER BY project, name, version"; got != want {
+		t.Errorf("ListModelsQuery() = %s, want %s", got, want)
+	}
+}
+
+func TestCreateModelStatement(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CreateModelOptions
+		want string
+	}{
+		{
+			name: "whole table",
+			opts: CreateModelOptions{Name: "home_rentals", From: "example_db.demo_data.home_rentals", Predict: "rental_price"},
+			want: "CREATE MODEL `home_rentals`\n" +
+				"FROM `example_db`\n" +
+				"  (SELECT * FROM `demo_data`.`home_rentals`)\n" +
+				"PREDICT `rental_price`",
+		},
+		{
+			name: "training query and parameters",
+			opts: CreateModelOptions{
+				Project: "sales",
+				Name:    "churn",
+				From:    "crm",
+				Select:  "SELECT * FROM customers WHERE active; ",
+				Predict: "churned",
+				Engine:  "lightwood",
+				Using:   []string{"tag=it's", "max_rows = 1000", "verbose=true", "encoders.location.module={\"module\": \"CategoricalAutoEncoder\"}"},
+			},
+			want: "CREATE MODEL `sales`.`churn`\n" +
+				"FROM `crm`\n" +
+				"  (SELECT * FROM customers WHERE active)\n" +
+				"PREDICT `churned`\n" +
+				"USING\n" +
+				"  engine = 'lightwood',\n" +
+				"  tag = 'it''s',\n" +
+				"  max_rows = 1000,\n" +
+				"  verbose = true,\n" +
+				"  encoders.location.module = {\"module\": \"CategoricalAutoEncoder\"}",
+		},
+		{
+			name: "time series",
+			opts: CreateModelOptions{
+				Name: "forecast", From: "shop.orders", Predict: "amount",
+				OrderBy: "date", GroupBy: []string{"region", "store`id"}, Window: 12, Horizon: 3,
+			},
+			want: "CREATE MODEL `forecast`\n" +
+				"FROM `shop`\n" +
+				"  (SELECT * FROM `orders`)\n" +
+				"PREDICT `amount`\n" +
+				"ORDER BY `date`\n" +
+				"GROUP BY `region`, `store``id`\n" +
+				"WINDOW 12\n" +
+				"HORIZON 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.Statement()
+			if err != nil {
+				t.Fatalf("Statement() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Statement() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateModelValidate(t *testing.T) {
+	valid := CreateModelOptions{Name: "m", From: "db.table", Predict: "y"}
+	tests := []struct {
+		name    string
+		change  func(*CreateModelOptions)
+		wantErr string
+	}{
+		{"missing name", func(o *CreateModelOptions) { o.Name = " " }, "a model name is required"},
+		{"dotted name", func(o *CreateModelOptions) { o.Name = "sales.m" }, "use --project"},
+		{"missing target", func(o *CreateModelOptions) { o.Predict = "" }, "a target column is required"},
+		{"select without from", func(o *CreateModelOptions) { o.From, o.Select = "", "SELECT 1" }, "--select needs --from"},
+		{"from without table", func(o *CreateModelOptions) { o.From = "db" }, "--from db names no table"},
+		{"negative window", func(o *CreateModelOptions) { o.OrderBy, o.Window = "date", -1 }, "must be positive"},
+		{"time series without order", func(o *CreateModelOptions) { o.Horizon = 3 }, "need --order-by"},
+		{"using without value", func(o *CreateModelOptions) { o.Using = []string{"verbose"} }, "expected key=value"},
+		{"using injection", func(o *CreateModelOptions) { o.Using = []string{"a, b = 2"} }, "expected key=value"},
+		{"using engine", func(o *CreateModelOptions) { o.Using = []string{"Engine=openai"} }, "use --engine"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.change(&opts)
+			_, err := opts.Statement()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Statement() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// modelStates answers ModelStatus queries with one state per poll,
+// repeating the last one
+func modelStates(states ...[]interface{}) func(string) (*memoryRows, error) {
+	polls := 0
+	return func(query string) (*memoryRows, error) {
+		if !strings.Contains(query, "FROM information_schema.models WHERE project = 'sales' AND name = 'forecast'") {
+			return nil, errors.New("unexpected query: " + query)
+		}
+		state := states[min(polls, len(states)-1)]
+		polls++
+		if state == nil {
+			return table([]string{"version", "status", "error"}), nil
+		}
+		return table([]string{"version", "status", "error"}, state), nil
+	}
+}
+
+func TestWaitForModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  [][]interface{}
+		status  string
+		wantErr string
+	}{
+		{
+			name:   "completes",
+			states: [][]interface{}{{1, "generating", nil}, {1, "training", nil}, {1, "complete", nil}},
+			status: ModelStatusComplete,
+		},
+		{
+			name:    "fails",
+			states:  [][]interface{}{{2, "training", nil}, {2, "error", "Column 'amount' not found"}},
+			status:  ModelStatusError,
+			wantErr: "training model sales.forecast failed: Column 'amount' not found",
+		},
+		{
+			name:    "missing",
+			states:  [][]interface{}{nil},
+			wantErr: "model sales.forecast not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MindsDBClient{Conn: &fakeExecutor{respond: modelStates(tt.states...)}}
+
+			var polled []string
+			state, err := client.WaitForModel(context.Background(), "sales", "forecast", time.Millisecond, func(s *ModelState) {
+				polled = append(polled, s.Status)
+			})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("WaitForModel() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("WaitForModel() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.status == "" {
+				return
+			}
+			if state.Status != tt.status {
+				t.Errorf("status = %s, want %s", state.Status, tt.status)
+			}
+			if len(polled) != len(tt.states) {
+				t.Errorf("polled %v, want %d polls", polled, len(tt.states))
+			}
+		})
+	}
+}
+
+func TestWaitForModelTimeout(t *testing.T) {
+	client := &MindsDBClient{Conn: &fakeExecutor{respond: modelStates([]interface{}{1, "training", nil})}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitForModel(ctx, "sales", "forecast", time.Millisecond, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForModel() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestTrainingErrorIsTyped(t *testing.T) {
+	client := &MindsDBClient{Conn: &fakeExecutor{respond: modelStates([]interface{}{1, "error", ""})}}
+
+	_, err := client.WaitForModel(context.Background(), "sales", "forecast", time.Millisecond, nil)
+	var trainingErr *ModelTrainingError
+	if !errors.As(err, &trainingErr) || trainingErr.Model != "sales.forecast" {
+		t.Errorf("WaitForModel() error = %#v, want a *ModelTrainingError for sales.forecast", err)
+	}
+}
+
+func TestDescribeModelQuery(t *testing.T) {
+	tests := []struct{ project, name, section, want string }{
+		{"", "home_rentals", DescribeInfo, "DESCRIBE `mindsdb`.`home_rentals`"},
+		{"sales", "churn", DescribeFeatures, "DESCRIBE `sales`.`churn`.features"},
+		{"my`project", "m", DescribeEnsemble, "DESCRIBE `my``project`.`m`.ensemble"},
+	}
+	for _, tt := range tests {
+		if got := DescribeModelQuery(tt.project, tt.name, tt.section); got != tt.want {
+			t.Errorf("DescribeModelQuery(%q, %q, %q) = %s, want %s", tt.project, tt.name, tt.section, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package mindsdb
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// sqlExecutor runs queries through a database/sql pool, which is how the
+// MySQL API is reached
+type sqlExecutor struct {
+	db *sql.DB
+}
+
+// mysqlConfig builds the driver configuration for a MindsDB MySQL endpoint
+func mysqlConfig(user, pass, addr, database string, tlsConfig *tls.Config) *mysql.Config {
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = pass
+	cfg.Net = "tcp"
+	cfg.Addr = addr
+	cfg.DBName = database
+	cfg.TLS = tlsConfig
+	return cfg
+}
+
+// newMySQLExecutor connects to MindsDB using the MySQL protocol and verifies
+// the connection with a ping bounded by ctx
+func newMySQLExecutor(ctx context.Context, cfg *mysql.Config) (*sqlExecutor, error) {
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db := sql.OpenDB(connector)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqlExecutor{db: db}, nil
+}
+
+func (e *sqlExecutor) Query(ctx context.Context, query string) (Rows, error) {
+	return e.db.QueryContext(ctx, query)
+}
+
+func (e *sqlExecutor) Exec(ctx context.Context, query string) error {
+	_, err := e.db.ExecContext(ctx, query)
+	return err
+}
+
+func (e *sqlExecutor) Ping(ctx context.Context) error {
+	return e.db.PingContext(ctx)
+}
+
+func (e *sqlExecutor) Close() error {
+	return e.db.Close()
+}
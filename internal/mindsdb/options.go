@@ -0,0 +1,305 @@
+package mindsdb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OptionsLabel records the ContainerOptions a container was created with,
+// so later starts can detect drift
+const OptionsLabel = "mindsdb-cli.options"
+
+// ContainerOptions are the optional settings of an embedded container
+type ContainerOptions struct {
+	CPUs    float64  // CPU limit, e.g. 1.5
+	Memory  int64    // Memory limit in bytes
+	Env     []string // Extra KEY=VALUE variables
+	Network string   // Network to attach to
+	Mounts  []string // source:target[:ro]
+
+	envHashes map[string]string // Recorded value hashes by key, for options read from a label
+}
+
+// optionsLabel is how ContainerOptions are stored in OptionsLabel. Labels
+// can be read by anyone who can inspect the container, and --env may copy
+// secrets from the host, so only a hash of each value is kept.
+type optionsLabel struct {
+	CPUs    float64           `json:"cpus,omitempty"`
+	Memory  int64             `json:"memory,omitempty"`
+	Env     map[string]string `json:"env_sha256,omitempty"` // Key to hex SHA-256 of the value
+	Network string            `json:"network,omitempty"`
+	Mounts  []string          `json:"mounts,omitempty"`
+}
+
+// IsZero reports whether no option is set
+func (o ContainerOptions) IsZero() bool {
+	return o.CPUs == 0 && o.Memory == 0 && len(o.Env) == 0 && len(o.envHashes) == 0 && o.Network == "" && len(o.Mounts) == 0
+}
+
+// ParseContainerOptions validates and normalizes the raw flag values.
+// Environment entries without a value take it from the current environment;
+// relative bind mount sources are made absolute.
+func ParseContainerOptions(cpus, memory string, env []string, network string, mounts []string) (ContainerOptions, error) {
+	var opts ContainerOptions
+	var err error
+
+	if cpus != "" {
+		if opts.CPUs, err = strconv.ParseFloat(cpus, 64); err != nil || opts.CPUs <= 0 {
+			return opts, fmt.Errorf("invalid --cpus value %q (expected a positive number such as 1.5)", cpus)
+		}
+	}
+	if memory != "" {
+		if opts.Memory, err = parseMemory(memory); err != nil {
+			return opts, err
+		}
+	}
+
+	for _, entry := range env {
+		key, _, hasValue := strings.Cut(entry, "=")
+		if key == "" {
+			return opts, fmt.Errorf("invalid --env value %q (expected KEY=VALUE)", entry)
+		}
+		if !hasValue {
+			value, ok := os.LookupEnv(key)
+			if !ok {
+				return opts, fmt.Errorf("--env %s: variable is not set in the current environment", key)
+			}
+			entry = key + "=" + value
+		}
+		opts.Env = append(opts.Env, entry)
+	}
+	sort.Strings(opts.Env)
+
+	opts.Network = network
+
+	for _, mount := range mounts {
+		normalized, err := parseMount(mount)
+		if err != nil {
+			return opts, err
+		}
+		opts.Mounts = append(opts.Mounts, normalized)
+	}
+	sort.Strings(opts.Mounts)
+	return opts, nil
+}
+
+// parseMemory converts sizes such as 512m or 4g to bytes
+func parseMemory(value string) (int64, error) {
+	units := map[byte]int64{'b': 1, 'k': 1 << 10, 'm': 1 << 20, 'g': 1 << 30}
+	number := strings.TrimSuffix(strings.ToLower(value), "b")
+	multiplier := int64(1)
+	if n := len(number); n > 0 {
+		if unit, ok := units[number[n-1]]; ok {
+			multiplier = unit
+			number = number[:n-1]
+		}
+	}
+
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid --memory value %q (expected a size such as 512m or 4g)", value)
+	}
+	bytes := int64(size * float64(multiplier))
+	if bytes < 6<<20 {
+		return 0, fmt.Errorf("invalid --memory value %q (the minimum is 6m)", value)
+	}
+	return bytes, nil
+}
+
+// formatMemory renders a byte count the way --memory accepts it
+func formatMemory(bytes int64) string {
+	switch {
+	case bytes == 0:
+		return "unlimited"
+	case bytes%(1<<30) == 0:
+		return fmt.Sprintf("%dg", bytes>>30)
+	case bytes%(1<<20) == 0:
+		return fmt.Sprintf("%dm", bytes>>20)
+	default:
+		return strconv.FormatInt(bytes, 10)
+	}
+}
+
+// parseMount validates a source:target[:ro] mount
+func parseMount(mount string) (string, error) {
+	parts := strings.Split(mount, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || !strings.HasPrefix(parts[1], "/") {
+		return "", fmt.Errorf("invalid --mount value %q (expected source:/container/path[:ro])", mount)
+	}
+	if len(parts) == 3 && parts[2] != "ro" && parts[2] != "rw" {
+		return "", fmt.Errorf("invalid --mount mode %q (expected ro or rw)", parts[2])
+	}
+	if parts[1] == StoragePath {
+		return "", fmt.Errorf("--mount cannot target %s, which holds the instance's data volume", StoragePath)
+	}
+
+	// Paths are bind mounts, anything else is a named volume
+	if strings.HasPrefix(parts[0], ".") || strings.HasPrefix(parts[0], "/") || strings.HasPrefix(parts[0], "~") {
+		source := parts[0]
+		if rest, ok := strings.CutPrefix(source, "~"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			source = home + rest
+		}
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return "", fmt.Errorf("--mount source %s: %w", abs, err)
+		}
+		parts[0] = abs
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+// containerOptions returns the options recorded on a container. The
+// environment values are taken from the container's environment where they
+// still match the recorded hashes, so the options can be used to recreate
+// it.
+func containerOptions(info *ContainerInfo) ContainerOptions {
+	var recorded optionsLabel
+	if label := info.Labels[OptionsLabel]; label != "" {
+		json.Unmarshal([]byte(label), &recorded)
+	}
+
+	opts := ContainerOptions{
+		CPUs:      recorded.CPUs,
+		Memory:    recorded.Memory,
+		Network:   recorded.Network,
+		Mounts:    recorded.Mounts,
+		envHashes: recorded.Env,
+	}
+	current := envMap(info.Env)
+	for _, key := range sortedKeys(recorded.Env) {
+		if value, ok := current[key]; ok && hashEnvValue(value) == recorded.Env[key] {
+			opts.Env = append(opts.Env, key+"="+value)
+		}
+	}
+	return opts
+}
+
+// label encodes the options for OptionsLabel
+func (o ContainerOptions) label() string {
+	data, _ := json.Marshal(optionsLabel{
+		CPUs:    o.CPUs,
+		Memory:  o.Memory,
+		Env:     o.hashedEnv(),
+		Network: o.Network,
+		Mounts:  o.Mounts,
+	})
+	return string(data)
+}
+
+// hashedEnv returns the hash of each environment value by key
+func (o ContainerOptions) hashedEnv() map[string]string {
+	if o.envHashes != nil {
+		return o.envHashes
+	}
+	if len(o.Env) == 0 {
+		return nil
+	}
+	hashes := make(map[string]string, len(o.Env))
+	for key, value := range envMap(o.Env) {
+		hashes[key] = hashEnvValue(value)
+	}
+	return hashes
+}
+
+func hashEnvValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// Diff describes how other differs from o, one change per entry.
+// Environment values are compared by hash and never shown, since they may
+// hold secrets.
+func (o ContainerOptions) Diff(other ContainerOptions) []string {
+	var changes []string
+	if o.CPUs != other.CPUs {
+		changes = append(changes, fmt.Sprintf("cpus: %s → %s", formatCPUs(o.CPUs), formatCPUs(other.CPUs)))
+	}
+	if o.Memory != other.Memory {
+		changes = append(changes, fmt.Sprintf("memory: %s → %s", formatMemory(o.Memory), formatMemory(other.Memory)))
+	}
+	if o.Network != other.Network {
+		changes = append(changes, fmt.Sprintf("network: %s → %s", orDefault(o.Network), orDefault(other.Network)))
+	}
+
+	current, requested := o.hashedEnv(), other.hashedEnv()
+	for _, key := range sortedKeys(current, requested) {
+		value, had := current[key]
+		newValue, has := requested[key]
+		switch {
+		case had && !has:
+			changes = append(changes, "env: remove "+key)
+		case !had && has:
+			changes = append(changes, "env: add "+key)
+		case value != newValue:
+			changes = append(changes, "env: change "+key)
+		}
+	}
+
+	if strings.Join(o.Mounts, ",") != strings.Join(other.Mounts, ",") {
+		changes = append(changes, fmt.Sprintf("mounts: [%s] → [%s]", strings.Join(o.Mounts, ", "), strings.Join(other.Mounts, ", ")))
+	}
+	return changes
+}
+
+func formatCPUs(cpus float64) string {
+	if cpus == 0 {
+		return "unlimited"
+	}
+	return strconv.FormatFloat(cpus, 'f', -1, 64)
+}
+
+func orDefault(network string) string {
+	if network == "" {
+		return "default"
+	}
+	return network
+}
+
+func envMap(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, entry := range env {
+		key, value, _ := strings.Cut(entry, "=")
+		m[key] = value
+	}
+	return m
+}
+
+func sortedKeys(maps ...map[string]string) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ConfigDriftError is returned when an existing container was created with
+// different options or authentication than the ones requested
+type ConfigDriftError struct {
+	Container string
+	Changes   []string
+}
+
+func (e *ConfigDriftError) Error() string {
+	return fmt.Sprintf("container %s was created with different settings (%s)", e.Container, strings.Join(e.Changes, "; "))
+}
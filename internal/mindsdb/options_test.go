@@ -0,0 +1,220 @@
+package mindsdb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr string
+	}{
+		{"512m", 512 << 20, ""},
+		{"4g", 4 << 30, ""},
+		{"4G", 4 << 30, ""},
+		{"1.5g", 3 << 29, ""},
+		{"2gb", 2 << 30, ""},
+		{"10240k", 10 << 20, ""},
+		{"8388608", 8 << 20, ""},
+		{"5m", 0, "the minimum is 6m"},
+		{"0", 0, "expected a size"},
+		{"-1g", 0, "expected a size"},
+		{"lots", 0, "expected a size"},
+		{"", 0, "expected a size"},
+	}
+	for _, tt := range tests {
+		got, err := parseMemory(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseMemory(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseMemory(%q) = %d, %v; want %d", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatMemory(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "unlimited"},
+		{4 << 30, "4g"},
+		{512 << 20, "512m"},
+		{3 << 29, "1536m"},
+		{10<<20 + 1, "10485761"},
+	}
+	for _, tt := range tests {
+		if got := formatMemory(tt.bytes); got != tt.want {
+			t.Errorf("formatMemory(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestParseMount(t *testing.T) {
+	dir := t.TempDir()
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	if err := os.Mkdir("data", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		mount   string
+		want    string
+		wantErr string
+	}{
+		{dir + ":/data", dir + ":/data", ""},
+		{"./data:/data:ro", filepath.Join(dir, "data") + ":/data:ro", ""},
+		{".:/src:rw", dir + ":/src:rw", ""},
+		{"~:/home", home + ":/home", ""},
+		{"models-cache:/root/.cache", "models-cache:/root/.cache", ""},
+		{"./missing:/data", "", "no such file or directory"},
+		{"data", "", "expected source:/container/path"},
+		{":/data", "", "expected source:/container/path"},
+		{"data:relative", "", "expected source:/container/path"},
+		{"a:/b:c:d", "", "expected source:/container/path"},
+		{"data:/data:rx", "", "expected ro or rw"},
+		{"other:" + StoragePath, "", "holds the instance's data volume"},
+	}
+	for _, tt := range tests {
+		got, err := parseMount(tt.mount)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseMount(%q) error = %v, want %q", tt.mount, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseMount(%q) = %q, %v; want %q", tt.mount, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseContainerOptions(t *testing.T) {
+	t.Setenv("MINDSDB_CLI_TEST_TOKEN", "from-host")
+
+	opts, err := ParseContainerOptions("1.5", "2g", []string{"B=2", "MINDSDB_CLI_TEST_TOKEN", "A="}, "backend", []string{"cache-b:/b", "cache-a:/a"})
+	if err != nil {
+		t.Fatalf("ParseContainerOptions() error = %v", err)
+	}
+	if opts.CPUs != 1.5 || opts.Memory != 2<<30 || opts.Network != "backend" {
+		t.Errorf("ParseContainerOptions() = %+v", opts)
+	}
+	if got := strings.Join(opts.Env, " "); got != "A= B=2 MINDSDB_CLI_TEST_TOKEN=from-host" {
+		t.Errorf("Env = %s", got)
+	}
+	if got := strings.Join(opts.Mounts, " "); got != "cache-a:/a cache-b:/b" {
+		t.Errorf("Mounts = %s", got)
+	}
+
+	for _, bad := range []struct {
+		cpus, env, wantErr string
+	}{
+		{"0", "", "invalid --cpus"},
+		{"many", "", "invalid --cpus"},
+		{"", "=x", "invalid --env"},
+		{"", "MINDSDB_CLI_TEST_UNSET", "not set in the current environment"},
+	} {
+		var env []string
+		if bad.env != "" {
+			env = []string{bad.env}
+		}
+		if _, err := ParseContainerOptions(bad.cpus, "", env, "", nil); err == nil || !strings.Contains(err.Error(), bad.wantErr) {
+			t.Errorf("ParseContainerOptions(cpus=%q, env=%q) error = %v, want %q", bad.cpus, bad.env, err, bad.wantErr)
+		}
+	}
+}
+
+func TestContainerOptionsDiff(t *testing.T) {
+	current := ContainerOptions{CPUs: 2, Env: []string{"KEEP=1", "OLD=1", "TOKEN=a"}, Mounts: []string{"cache:/cache"}}
+	requested := ContainerOptions{Memory: 4 << 30, Network: "backend", Env: []string{"KEEP=1", "NEW=1", "TOKEN=b"}, Mounts: []string{"cache:/cache"}}
+
+	want := []string{
+		"cpus: 2 → unlimited",
+		"memory: unlimited → 4g",
+		"network: default → backend",
+		"env: add NEW",
+		"env: remove OLD",
+		"env: change TOKEN",
+	}
+	got := current.Diff(requested)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Diff() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, change := range got {
+		if strings.Contains(change, "=a") || strings.Contains(change, "=b") {
+			t.Errorf("Diff() shows an environment value: %s", change)
+		}
+	}
+	if changes := current.Diff(current); len(changes) != 0 {
+		t.Errorf("Diff() with itself = %v", changes)
+	}
+}
+
+func TestContainerOptionsLabelRoundTrip(t *testing.T) {
+	opts := ContainerOptions{
+		CPUs:    0.5,
+		Memory:  1 << 30,
+		Env:     []string{"API_TOKEN=s3cr3t-t0ken", "LOG_LEVEL=debug"},
+		Network: "host",
+		Mounts:  []string{"/data:/data:ro"},
+	}
+	label := opts.label()
+	for _, value := range []string{"s3cr3t-t0ken", "debug"} {
+		if strings.Contains(label, value) {
+			t.Errorf("label %s contains the environment value %q", label, value)
+		}
+	}
+
+	// The values come back from the container's environment
+	info := &ContainerInfo{
+		Labels: map[string]string{OptionsLabel: label},
+		Env:    []string{"PATH=/usr/bin", "API_TOKEN=s3cr3t-t0ken", "LOG_LEVEL=debug", "MINDSDB_DB_SERVICE_HOST=0.0.0.0"},
+	}
+	recorded := containerOptions(info)
+	if diff := recorded.Diff(opts); len(diff) != 0 {
+		t.Errorf("options read back from the label differ: %v", diff)
+	}
+	if got := strings.Join(recorded.Env, " "); got != "API_TOKEN=s3cr3t-t0ken LOG_LEVEL=debug" {
+		t.Errorf("recorded Env = %s", got)
+	}
+	if recorded.label() != label {
+		t.Errorf("label of recorded options = %s, want %s", recorded.label(), label)
+	}
+
+	// Changes are found by hash, without the container's environment
+	withoutEnv := containerOptions(&ContainerInfo{Labels: map[string]string{OptionsLabel: label}})
+	if diff := withoutEnv.Diff(opts); len(diff) != 0 {
+		t.Errorf("Diff() without the container environment = %v", diff)
+	}
+	rotated := opts
+	rotated.Env = []string{"API_TOKEN=n3w-t0ken", "LOG_LEVEL=debug"}
+	if diff := withoutEnv.Diff(rotated); strings.Join(diff, ",") != "env: change API_TOKEN" {
+		t.Errorf("Diff() after changing a value = %v", diff)
+	}
+
+	if !containerOptions(&ContainerInfo{}).IsZero() {
+		t.Error("a container without the label has options")
+	}
+	if withoutEnv.IsZero() {
+		t.Error("options with only recorded environment hashes are zero")
+	}
+}
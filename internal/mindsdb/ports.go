@@ -0,0 +1,164 @@
+package mindsdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// PortsLabel records the host ports a container was created with, since
+// runtimes stop reporting published ports once the container is stopped
+const PortsLabel = "mindsdb-cli.ports"
+
+// Ports are the host ports an embedded instance publishes. The ports inside
+// the container are always MindsDBPort and MySQLPort.
+type Ports struct {
+	HTTP  string `json:"http" yaml:"http"`   // Web UI and HTTP API
+	MySQL string `json:"mysql" yaml:"mysql"` // MySQL wire protocol
+}
+
+// DefaultPorts returns the ports used when none are configured
+func DefaultPorts() Ports {
+	return Ports{HTTP: MindsDBPort, MySQL: MySQLPort}
+}
+
+// withDefaults fills unset ports with the defaults
+func (p Ports) withDefaults() Ports {
+	if p.HTTP == "" {
+		p.HTTP = MindsDBPort
+	}
+	if p.MySQL == "" {
+		p.MySQL = MySQLPort
+	}
+	return p
+}
+
+// PortInUseError reports a host port that is already taken
+type PortInUseError struct {
+	Name string // "HTTP" or "MySQL"
+	Port string
+	Err  error
+}
+
+func (e *PortInUseError) Error() string {
+	return fmt.Sprintf("%s port %s is already in use", e.Name, e.Port)
+}
+
+func (e *PortInUseError) Unwrap() error {
+	return e.Err
+}
+
+// validatePort checks that port is a valid TCP port number
+func validatePort(name, port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid %s port %q (must be between 1 and 65535)", name, port)
+	}
+	return nil
+}
+
+// checkPortFree verifies that nothing is listening on a host port by
+// binding it on all interfaces, as the container runtime will
+func checkPortFree(name, port string) error {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return &PortInUseError{Name: name, Port: port, Err: err}
+	}
+	return listener.Close()
+}
+
+// checkPortsFree verifies that both host ports are free
+func checkPortsFree(ports Ports) error {
+	if err := checkPortFree("HTTP", ports.HTTP); err != nil {
+		return err
+	}
+	return checkPortFree("MySQL", ports.MySQL)
+}
+
+// freePort asks the kernel for an unused host port
+func freePort() (string, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// freePorts picks a free HTTP and MySQL port
+func freePorts() (Ports, error) {
+	http, err := freePort()
+	if err != nil {
+		return Ports{}, fmt.Errorf("failed to find a free HTTP port: %w", err)
+	}
+	mysql, err := freePort()
+	for err == nil && mysql == http {
+		mysql, err = freePort()
+	}
+	if err != nil {
+		return Ports{}, fmt.Errorf("failed to find a free MySQL port: %w", err)
+	}
+	return Ports{HTTP: http, MySQL: mysql}, nil
+}
+
+// resolvePorts validates the requested ports and checks that they are free.
+// With auto set, a taken port is replaced by a free one instead of failing.
+func resolvePorts(requested Ports, auto bool) (Ports, error) {
+	ports := requested.withDefaults()
+	for _, p := range []struct {
+		name string
+		port *string
+	}{{"HTTP", &ports.HTTP}, {"MySQL", &ports.MySQL}} {
+		if err := validatePort(p.name, *p.port); err != nil {
+			return Ports{}, err
+		}
+		err := checkPortFree(p.name, *p.port)
+		if err == nil {
+			continue
+		}
+		if !auto {
+			return Ports{}, err
+		}
+		free, ferr := freePort()
+		if ferr != nil {
+			return Ports{}, fmt.Errorf("failed to find a free %s port: %w", p.name, ferr)
+		}
+		fmt.Printf("🔀 %s port %s is in use, using %s instead\n", p.name, *p.port, free)
+		*p.port = free
+	}
+	if ports.HTTP == ports.MySQL {
+		return Ports{}, fmt.Errorf("HTTP and MySQL ports must differ (both are %s)", ports.HTTP)
+	}
+	return ports, nil
+}
+
+// label encodes the ports for PortsLabel
+func (p Ports) label() string {
+	data, _ := json.Marshal(p)
+	return string(data)
+}
+
+// recordedPorts returns the host ports a container publishes, running or
+// not, and whether both are known. They are read from PortsLabel, or for
+// containers created without it from the runtime's port bindings.
+func recordedPorts(info *ContainerInfo) (Ports, bool) {
+	var ports Ports
+	if label := info.Labels[PortsLabel]; label != "" {
+		json.Unmarshal([]byte(label), &ports)
+	}
+	if ports.HTTP == "" {
+		ports.HTTP = info.Ports[MindsDBPort]
+	}
+	if ports.MySQL == "" {
+		ports.MySQL = info.Ports[MySQLPort]
+	}
+	return ports, ports.HTTP != "" && ports.MySQL != ""
+}
+
+// containerPorts returns the host ports a container publishes, falling back
+// to the defaults for any that are unknown
+func containerPorts(info *ContainerInfo) Ports {
+	ports, _ := recordedPorts(info)
+	return ports.withDefaults()
+}
@@ -0,0 +1,231 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// busyPort returns a host port that stays taken until the test ends
+func busyPort(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func testFreePort(t *testing.T) string {
+	t.Helper()
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func TestResolvePorts(t *testing.T) {
+	free, free2, busy := testFreePort(t), testFreePort(t), busyPort(t)
+
+	tests := []struct {
+		name      string
+		requested Ports
+		auto      bool
+		wantErr   string
+		check     func(t *testing.T, got Ports)
+	}{
+		{
+			name:      "free ports are kept",
+			requested: Ports{HTTP: free, MySQL: free2},
+			check: func(t *testing.T, got Ports) {
+				if got != (Ports{HTTP: free, MySQL: free2}) {
+					t.Errorf("ports = %+v", got)
+				}
+			},
+		},
+		{
+			name:      "taken port",
+			requested: Ports{HTTP: busy, MySQL: free},
+			wantErr:   "HTTP port " + busy + " is already in use",
+		},
+		{
+			name:      "taken port replaced with auto",
+			requested: Ports{HTTP: free, MySQL: busy},
+			auto:      true,
+			check: func(t *testing.T, got Ports) {
+				if got.HTTP != free || got.MySQL == busy || got.MySQL == "" {
+					t.Errorf("ports = %+v, want HTTP %s and a MySQL port other than %s", got, free, busy)
+				}
+			},
+		},
+		{
+			name:      "same port twice",
+			requested: Ports{HTTP: free, MySQL: free},
+			wantErr:   "HTTP and MySQL ports must differ",
+		},
+		{
+			name:      "port zero",
+			requested: Ports{HTTP: "0", MySQL: free},
+			wantErr:   `invalid HTTP port "0"`,
+		},
+		{
+			name:      "port out of range",
+			requested: Ports{HTTP: free, MySQL: "65536"},
+			wantErr:   `invalid MySQL port "65536"`,
+		},
+		{
+			name:      "not a number",
+			requested: Ports{HTTP: "web", MySQL: free},
+			auto:      true,
+			wantErr:   `invalid HTTP port "web"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePorts(tt.requested, tt.auto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolvePorts() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePorts() error = %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestResolvePortsReportsTakenPort(t *testing.T) {
+	busy := busyPort(t)
+	_, err := resolvePorts(Ports{HTTP: testFreePort(t), MySQL: busy}, false)
+
+	var inUse *PortInUseError
+	if !errors.As(err, &inUse) || inUse.Name != "MySQL" || inUse.Port != busy {
+		t.Errorf("resolvePorts() error = %#v, want a *PortInUseError for MySQL port %s", err, busy)
+	}
+}
+
+func TestRecordedPorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  *ContainerInfo
+		want  Ports
+		known bool
+	}{
+		{
+			name:  "label of a stopped container",
+			info:  &ContainerInfo{Labels: map[string]string{PortsLabel: Ports{HTTP: "48334", MySQL: "48335"}.label()}},
+			want:  Ports{HTTP: "48334", MySQL: "48335"},
+			known: true,
+		},
+		{
+			name: "label wins over bindings",
+			info: &ContainerInfo{
+				Labels: map[string]string{PortsLabel: `{"http":"48334","mysql":"48335"}`},
+				Ports:  map[string]string{MindsDBPort: "1", MySQLPort: "2"},
+			},
+			want:  Ports{HTTP: "48334", MySQL: "48335"},
+			known: true,
+		},
+		{
+			name:  "bindings of a container created without the label",
+			info:  &ContainerInfo{Ports: map[string]string{MindsDBPort: "48334", MySQLPort: "48335"}},
+			want:  Ports{HTTP: "48334", MySQL: "48335"},
+			known: true,
+		},
+		{
+			name: "partial label completed from bindings",
+			info: &ContainerInfo{
+				Labels: map[string]string{PortsLabel: `{"http":"48334"}`},
+				Ports:  map[string]string{MySQLPort: "48335"},
+			},
+			want:  Ports{HTTP: "48334", MySQL: "48335"},
+			known: true,
+		},
+		{
+			name: "malformed label",
+			info: &ContainerInfo{Labels: map[string]string{PortsLabel: "48334"}},
+		},
+		{
+			name: "stopped container without label",
+			info: &ContainerInfo{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := recordedPorts(tt.info)
+			if got != tt.want || known != tt.known {
+				t.Errorf("recordedPorts() = %+v, %v; want %+v, %v", got, known, tt.want, tt.known)
+			}
+			want := tt.want.withDefaults()
+			if got := containerPorts(tt.info); got != want {
+				t.Errorf("containerPorts() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestListInstancesReportsRecordedPorts(t *testing.T) {
+	rt := newFakeRuntime(
+		&ContainerInfo{
+			Name:   ContainerName,
+			Labels: map[string]string{InstanceLabel: DefaultInstance, PortsLabel: `{"http":"48334","mysql":"48335"}`},
+		},
+		&ContainerInfo{
+			Name:    ContainerName + "-scratch",
+			Running: true,
+			Labels:  map[string]string{InstanceLabel: "scratch"},
+			Ports:   map[string]string{MindsDBPort: "49334", MySQLPort: "49335"},
+		},
+		&ContainerInfo{
+			Name:   ContainerName + "-old",
+			Labels: map[string]string{InstanceLabel: "old"},
+		},
+	)
+
+	instances, err := ListInstances(context.Background(), rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Ports{
+		DefaultInstance: {HTTP: "48334", MySQL: "48335"},
+		"old":           DefaultPorts(),
+		"scratch":       {HTTP: "49334", MySQL: "49335"},
+	}
+	if len(instances) != len(want) {
+		t.Fatalf("ListInstances() returned %d instances, want %d", len(instances), len(want))
+	}
+	for _, instance := range instances {
+		if instance.Ports != want[instance.Name] {
+			t.Errorf("instance %s ports = %+v, want %+v", instance.Name, instance.Ports, want[instance.Name])
+		}
+	}
+}
+
+func TestStartStoppedContainerChecksRecordedPorts(t *testing.T) {
+	busy := busyPort(t)
+	rt := newFakeRuntime(&ContainerInfo{
+		Name:   ContainerName,
+		Status: "exited",
+		Labels: map[string]string{InstanceLabel: DefaultInstance, PortsLabel: Ports{HTTP: busy, MySQL: testFreePort(t)}.label()},
+	})
+	client := &MindsDBClient{EmbeddedMode: true, Runtime: rt}
+
+	_, err := client.StartEmbeddedMindsDB(EmbeddedOptions{})
+	if err == nil || !strings.Contains(err.Error(), "cannot start existing container: HTTP port "+busy+" is already in use") {
+		t.Errorf("StartEmbeddedMindsDB() error = %v", err)
+	}
+	if len(rt.calls) != 0 {
+		t.Errorf("runtime calls = %v, want none", rt.calls)
+	}
+	if client.Ports.HTTP != busy {
+		t.Errorf("client ports = %+v, want the recorded ones", client.Ports)
+	}
+}
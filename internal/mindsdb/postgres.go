@@ -0,0 +1,120 @@
+package mindsdb
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// pgExecutor runs queries over MindsDB's PostgreSQL API using pgx
+type pgExecutor struct {
+	config *pgx.ConnConfig
+	conn   *pgx.Conn
+}
+
+// newPostgresExecutor connects to MindsDB using the PostgreSQL protocol.
+// A non-nil tlsConfig replaces whatever sslmode the DSN asked for.
+func newPostgresExecutor(ctx context.Context, dsn string, tlsConfig *tls.Config) (*pgExecutor, error) {
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection settings: %w", err)
+	}
+	// MindsDB's PostgreSQL API does not support prepared statements
+	config.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+
+	if tlsConfig != nil {
+		config.TLSConfig = tlsConfig
+		config.Fallbacks = nil // never fall back to plain text
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return &pgExecutor{config: config, conn: conn}, nil
+}
+
+// ensureConn reconnects when the previous connection was closed, which pgx
+// does whenever a statement is cancelled through its context
+func (e *pgExecutor) ensureConn(ctx context.Context) error {
+	if !e.conn.IsClosed() {
+		return nil
+	}
+	conn, err := pgx.ConnectConfig(ctx, e.config)
+	if err != nil {
+		return fmt.Errorf("failed to reconnect: %w", err)
+	}
+	e.conn = conn
+	return nil
+}
+
+func (e *pgExecutor) Query(ctx context.Context, query string) (Rows, error) {
+	if err := e.ensureConn(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := e.conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// pgx reports server errors lazily; statements without a result set
+	// have to be drained here so failures are not mistaken for success
+	if len(rows.FieldDescriptions()) == 0 {
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return &pgRows{rows: rows}, nil
+}
+
+func (e *pgExecutor) Exec(ctx context.Context, query string) error {
+	if err := e.ensureConn(ctx); err != nil {
+		return err
+	}
+	_, err := e.conn.Exec(ctx, query)
+	return err
+}
+
+func (e *pgExecutor) Ping(ctx context.Context) error {
+	if err := e.ensureConn(ctx); err != nil {
+		return err
+	}
+	return e.conn.Ping(ctx)
+}
+
+func (e *pgExecutor) Close() error {
+	return e.conn.Close(context.Background())
+}
+
+// pgRows adapts pgx.Rows to the Rows interface
+type pgRows struct {
+	rows pgx.Rows
+}
+
+func (r *pgRows) Columns() ([]string, error) {
+	fields := r.rows.FieldDescriptions()
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.Name
+	}
+	return columns, nil
+}
+
+func (r *pgRows) Next() bool {
+	return r.rows.Next()
+}
+
+func (r *pgRows) Scan(dest ...interface{}) error {
+	return r.rows.Scan(dest...)
+}
+
+func (r *pgRows) Err() error {
+	return r.rows.Err()
+}
+
+func (r *pgRows) Close() error {
+	r.rows.Close()
+	return r.rows.Err()
+}
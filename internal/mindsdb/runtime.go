@@ -0,0 +1,243 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Container runtimes supported for the embedded instance
+const (
+	RuntimeAuto    = "auto"
+	RuntimeDocker  = "docker"
+	RuntimePodman  = "podman"
+	RuntimeNerdctl = "nerdctl"
+)
+
+// EnvRuntime selects the container runtime when --runtime is not given
+const EnvRuntime = "MINDSDB_CLI_RUNTIME"
+
+// ErrContainerNotFound is returned by InspectContainer for unknown containers
+var ErrContainerNotFound = errors.New("container not found")
+
+// ErrImageNotFound is returned by InspectImage for images not present locally
+var ErrImageNotFound = errors.New("image not found")
+
+// ContainerSpec describes a container to create
+type ContainerSpec struct {
+	Name    string
+	Image   string
+	Env     []string
+	Labels  map[string]string
+	Ports   map[string]string // Container port (e.g. "47334") to host port
+	Volumes map[string]string // Named volume to mount path; created if missing
+	CPUs    float64           // CPU limit; unlimited when zero
+	Memory  int64             // Memory limit in bytes; unlimited when zero
+	Network string            // Network to attach to; the runtime default when empty
+	Mounts  []string          // Extra source:target[:ro] bind mounts or volumes
+}
+
+// ContainerInfo is the runtime-independent view of a container
+type ContainerInfo struct {
+	ID        string
+	Name      string
+	Image     string // Image reference the container was created from
+	ImageID   string // ID of the image the container was created from
+	Status    string // e.g. running, exited, created
+	Running   bool
+	StartedAt string
+	Health    string // Health check status, empty when the image has none
+	Labels    map[string]string
+	Env       []string          // KEY=VALUE environment of the container
+	Ports     map[string]string // Container port to published host port
+	Volumes   map[string]string // Mounted named volume to mount path
+}
+
+// ImageInfo describes a local image
+type ImageInfo struct {
+	ID          string
+	RepoDigests []string // e.g. mindsdb/mindsdb@sha256:...
+}
+
+// LogsOptions controls which container logs are returned
+type LogsOptions struct {
+	Follow     bool
+	Since      string // Timestamp or relative duration such as 10m
+	Tail       string // Number of lines from the end, or "all"
+	Timestamps bool
+}
+
+// ContainerStats is a snapshot of a container's resource usage
+type ContainerStats struct {
+	CPUPercent  float64 `json:"cpu_percent" yaml:"cpu_percent"`   // Share of one CPU, so it can exceed 100 on several CPUs
+	MemoryUsage int64   `json:"memory_usage" yaml:"memory_usage"` // Bytes, excluding the page cache
+	MemoryLimit int64   `json:"memory_limit" yaml:"memory_limit"` // Bytes; the host memory when unlimited
+}
+
+// Runtime manages the lifecycle of the embedded MindsDB container. Docker is
+// driven through its Engine API; Podman and nerdctl through their
+// Docker-compatible CLIs.
+type Runtime interface {
+	// Name returns the runtime name, e.g. docker
+	Name() string
+	// Ping checks that the runtime is installed and usable
+	Ping(ctx context.Context) error
+	// Version returns the runtime version
+	Version(ctx context.Context) (string, error)
+	// PullImage downloads an image
+	PullImage(ctx context.Context, ref string) error
+	// LoadImage loads images from a tar archive and returns their references
+	LoadImage(ctx context.Context, archive io.Reader) ([]string, error)
+	// SaveImage writes a tar archive of an image to w
+	SaveImage(ctx context.Context, ref string, w io.Writer) error
+	// InspectImage looks up a local image and returns ErrImageNotFound if it
+	// is not present
+	InspectImage(ctx context.Context, ref string) (*ImageInfo, error)
+	// CreateContainer creates a container without starting it
+	CreateContainer(ctx context.Context, spec ContainerSpec) (string, error)
+	// StartContainer starts a created or stopped container
+	StartContainer(ctx context.Context, id string) error
+	// StopContainer stops a container; a nil timeout uses the runtime default
+	StopContainer(ctx context.Context, id string, timeout *time.Duration) error
+	// RemoveContainer removes a stopped container
+	RemoveContainer(ctx context.Context, id string) error
+	// InspectContainer looks up a container by ID or name and returns
+	// ErrContainerNotFound if it does not exist
+	InspectContainer(ctx context.Context, id string) (*ContainerInfo, error)
+	// ListContainers returns all containers, running or not, that carry
+	// the given label
+	ListContainers(ctx context.Context, label string) ([]*ContainerInfo, error)
+	// CopyFromContainer returns a tar archive of path inside a container
+	CopyFromContainer(ctx context.Context, id, path string) (io.ReadCloser, error)
+	// CopyToContainer extracts a tar archive into dir inside a container
+	CopyToContainer(ctx context.Context, id, dir string, archive io.Reader) error
+	// VolumeExists reports whether a named volume exists
+	VolumeExists(ctx context.Context, name string) (bool, error)
+	// RemoveVolume deletes a named volume; missing volumes are ignored
+	RemoveVolume(ctx context.Context, name string) error
+	// ContainerLogs returns the combined stdout and stderr of a container
+	ContainerLogs(ctx context.Context, id string, opts LogsOptions) (io.ReadCloser, error)
+	// ContainerStats returns the current resource usage of a running
+	// container
+	ContainerStats(ctx context.Context, id string) (*ContainerStats, error)
+}
+
+// parseLoadedImages extracts image references from the output of an image
+// load, e.g. "Loaded image: mindsdb/mindsdb:latest", from older Podman
+// "Loaded image(s): docker.io/mindsdb/mindsdb:latest" and from older nerdctl
+// "unpacking docker.io/mindsdb/mindsdb:latest (sha256:...)...done"
+func parseLoadedImages(output string) []string {
+	var refs []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(line, "unpacking "); ok {
+			if ref, _, found := strings.Cut(rest, " ("); found && ref != "" {
+				refs = append(refs, ref)
+			}
+			continue
+		}
+		for _, prefix := range []string{"Loaded image: ", "Loaded image(s): ", "Loaded image ID: "} {
+			if rest, ok := strings.CutPrefix(line, prefix); ok {
+				for _, ref := range strings.Split(rest, ",") {
+					if ref = strings.TrimSpace(ref); ref != "" {
+						refs = append(refs, ref)
+					}
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// inspectAll inspects each container in ids, skipping containers removed in
+// the meantime
+func inspectAll(ctx context.Context, rt Runtime, ids []string) ([]*ContainerInfo, error) {
+	containers := make([]*ContainerInfo, 0, len(ids))
+	for _, id := range ids {
+		info, err := rt.InspectContainer(ctx, id)
+		if errors.Is(err, ErrContainerNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, info)
+	}
+	return containers, nil
+}
+
+// NewRuntime returns the named runtime without checking that it works
+func NewRuntime(name string) (Runtime, error) {
+	switch name {
+	case RuntimeDocker:
+		docker, err := NewDockerClient()
+		if err != nil {
+			return nil, err
+		}
+		return &dockerRuntime{docker: docker}, nil
+	case RuntimePodman, RuntimeNerdctl:
+		return &cliRuntime{bin: name}, nil
+	default:
+		return nil, fmt.Errorf("unsupported container runtime %q (use auto, docker, podman or nerdctl)", name)
+	}
+}
+
+// DetectRuntime returns a usable container runtime. name selects one
+// explicitly; when it is empty or "auto" the MINDSDB_CLI_RUNTIME variable is
+// consulted, then docker, podman and nerdctl are tried in that order.
+func DetectRuntime(name string) (Runtime, error) {
+	if name == "" || name == RuntimeAuto {
+		name = os.Getenv(EnvRuntime)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if name != "" && name != RuntimeAuto {
+		rt, err := NewRuntime(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := rt.Ping(ctx); err != nil {
+			return nil, fmt.Errorf("%s is not available: %w", name, err)
+		}
+		return rt, nil
+	}
+
+	var reasons []string
+	for _, candidate := range []string{RuntimeDocker, RuntimePodman, RuntimeNerdctl} {
+		rt, err := NewRuntime(candidate)
+		if err == nil {
+			err = rt.Ping(ctx)
+		}
+		if err == nil {
+			return rt, nil
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %v", candidate, err))
+	}
+	return nil, fmt.Errorf("no container runtime available (%s)", strings.Join(reasons, "; "))
+}
+
+// parseSince converts a --since value into the Unix timestamp the Docker
+// API expects. It accepts RFC 3339 timestamps, Unix timestamps and relative
+// durations such as 10m.
+func parseSince(since string, now time.Time) (string, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return strconv.FormatInt(now.Add(-d).Unix(), 10), nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+	if t, err := time.Parse("2006-01-02", since); err == nil {
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+	if _, err := strconv.ParseInt(since, 10, 64); err == nil {
+		return since, nil
+	}
+	return "", fmt.Errorf("invalid --since value %q (use a duration such as 10m or a timestamp)", since)
+}
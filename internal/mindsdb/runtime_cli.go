@@ -0,0 +1,355 @@
+package mindsdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cliRuntime implements Runtime by running a Docker-compatible CLI such as
+// podman or nerdctl
+type cliRuntime struct {
+	bin string
+}
+
+func (r *cliRuntime) Name() string {
+	return r.bin
+}
+
+// run executes the runtime CLI and returns its trimmed stdout. On failure
+// the error carries the CLI's stderr.
+func (r *cliRuntime) run(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.bin, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s is not installed", r.bin)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (r *cliRuntime) Ping(ctx context.Context) error {
+	_, err := r.run(ctx, "info")
+	return err
+}
+
+func (r *cliRuntime) Version(ctx context.Context) (string, error) {
+	return r.run(ctx, "version", "--format", "{{.Client.Version}}")
+}
+
+func (r *cliRuntime) PullImage(ctx context.Context, ref string) error {
+	_, err := r.run(ctx, "pull", "--quiet", qualifyImage(ref))
+	return err
+}
+
+func (r *cliRuntime) LoadImage(ctx context.Context, archive io.Reader) ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.bin, "load")
+	cmd.Stdin = archive
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return parseLoadedImages(stdout.String() + "\n" + stderr.String()), nil
+}
+
+func (r *cliRuntime) SaveImage(ctx context.Context, ref string, w io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.bin, "save", qualifyImage(ref))
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *cliRuntime) InspectImage(ctx context.Context, ref string) (*ImageInfo, error) {
+	out, err := r.run(ctx, "image", "inspect", qualifyImage(ref))
+	if err != nil {
+		if isCLINotFound(err) {
+			return nil, ErrImageNotFound
+		}
+		return nil, err
+	}
+
+	var images []ImageJSON
+	if err := json.Unmarshal([]byte(out), &images); err != nil {
+		return nil, fmt.Errorf("failed to decode %s image inspect output: %w", r.bin, err)
+	}
+	if len(images) == 0 {
+		return nil, ErrImageNotFound
+	}
+	return &ImageInfo{ID: images[0].ID, RepoDigests: images[0].RepoDigests}, nil
+}
+
+func (r *cliRuntime) CreateContainer(ctx context.Context, spec ContainerSpec) (string, error) {
+	args := []string{"create", "--name", spec.Name}
+	for _, env := range spec.Env {
+		args = append(args, "--env", env)
+	}
+	for key, value := range spec.Labels {
+		args = append(args, "--label", key+"="+value)
+	}
+	for volume, path := range spec.Volumes {
+		args = append(args, "--volume", volume+":"+path)
+	}
+	for _, mount := range spec.Mounts {
+		args = append(args, "--volume", mount)
+	}
+	for containerPort, hostPort := range spec.Ports {
+		args = append(args, "--publish", hostPort+":"+containerPort)
+	}
+	if spec.CPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(spec.CPUs, 'f', -1, 64))
+	}
+	if spec.Memory > 0 {
+		args = append(args, "--memory", strconv.FormatInt(spec.Memory, 10))
+	}
+	if spec.Network != "" {
+		args = append(args, "--network", spec.Network)
+	}
+	args = append(args, qualifyImage(spec.Image))
+	return r.run(ctx, args...)
+}
+
+func (r *cliRuntime) StartContainer(ctx context.Context, id string) error {
+	_, err := r.run(ctx, "start", id)
+	return err
+}
+
+func (r *cliRuntime) StopContainer(ctx context.Context, id string, timeout *time.Duration) error {
+	args := []string{"stop"}
+	if timeout != nil {
+		args = append(args, "--time", strconv.Itoa(int(timeout.Seconds())))
+	}
+	_, err := r.run(ctx, append(args, id)...)
+	return err
+}
+
+func (r *cliRuntime) RemoveContainer(ctx context.Context, id string) error {
+	_, err := r.run(ctx, "rm", id)
+	return err
+}
+
+func (r *cliRuntime) InspectContainer(ctx context.Context, id string) (*ContainerInfo, error) {
+	out, err := r.run(ctx, "container", "inspect", id)
+	if err != nil {
+		if isCLINotFound(err) {
+			return nil, ErrContainerNotFound
+		}
+		return nil, err
+	}
+
+	var containers []ContainerJSON
+	if err := json.Unmarshal([]byte(out), &containers); err != nil {
+		return nil, fmt.Errorf("failed to decode %s inspect output: %w", r.bin, err)
+	}
+	if len(containers) == 0 {
+		return nil, ErrContainerNotFound
+	}
+	return containers[0].info(), nil
+}
+
+func (r *cliRuntime) ListContainers(ctx context.Context, label string) ([]*ContainerInfo, error) {
+	out, err := r.run(ctx, "ps", "--all", "--quiet", "--no-trunc", "--filter", "label="+label)
+	if err != nil {
+		return nil, err
+	}
+	return inspectAll(ctx, r, strings.Fields(out))
+}
+
+func (r *cliRuntime) CopyFromContainer(ctx context.Context, id, path string) (io.ReadCloser, error) {
+	var stderr bytes.Buffer
+	reader, writer := io.Pipe()
+	cmd := exec.CommandContext(ctx, r.bin, "cp", id+":"+path, "-")
+	cmd.Stdout = writer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() {
+		err := cmd.Wait()
+		if msg := strings.TrimSpace(stderr.String()); err != nil && msg != "" {
+			err = errors.New(msg)
+		}
+		writer.CloseWithError(err)
+	}()
+	return reader, nil
+}
+
+func (r *cliRuntime) CopyToContainer(ctx context.Context, id, dir string, archive io.Reader) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.bin, "cp", "-", id+":"+dir)
+	cmd.Stdin = archive
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *cliRuntime) VolumeExists(ctx context.Context, name string) (bool, error) {
+	_, err := r.run(ctx, "volume", "inspect", name)
+	if err != nil && isCLINotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *cliRuntime) RemoveVolume(ctx context.Context, name string) error {
+	_, err := r.run(ctx, "volume", "rm", name)
+	if err != nil && isCLINotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *cliRuntime) ContainerLogs(ctx context.Context, id string, opts LogsOptions) (io.ReadCloser, error) {
+	args := []string{"logs"}
+	if opts.Follow {
+		args = append(args, "--follow")
+	}
+	if opts.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	if opts.Tail != "" {
+		args = append(args, "--tail", opts.Tail)
+	}
+	if opts.Since != "" {
+		args = append(args, "--since", opts.Since)
+	}
+	args = append(args, id)
+
+	// The CLI writes the container's stderr to its own stderr, so both
+	// streams go into the same pipe
+	reader, writer := io.Pipe()
+	cmd := exec.CommandContext(ctx, r.bin, args...)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() {
+		writer.CloseWithError(cmd.Wait())
+	}()
+	return reader, nil
+}
+
+// qualifyImage prefixes short image names with docker.io, since Podman may
+// refuse to pull unqualified names when no search registries are configured
+func qualifyImage(ref string) string {
+	if strings.HasPrefix(ref, "sha256:") {
+		return ref // Image ID
+	}
+	first, _, found := strings.Cut(ref, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return ref
+	}
+	return "docker.io/" + ref
+}
+
+// cliNotFoundMessages are the messages podman and nerdctl print for a
+// container, volume or image that does not exist. A bare "not found" is not
+// enough: it is also printed for missing executables and registry errors.
+var cliNotFoundMessages = []string{
+	"no such container",
+	"no such volume",
+	"no such image",
+	"image not known",
+	"no such object",
+}
+
+// isCLINotFound reports whether a CLI error says the container, volume or
+// image does not exist
+func isCLINotFound(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, notFound := range cliNotFoundMessages {
+		if strings.Contains(msg, notFound) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *cliRuntime) ContainerStats(ctx context.Context, id string) (*ContainerStats, error) {
+	out, err := r.run(ctx, "stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}", id)
+	if err != nil {
+		if isCLINotFound(err) {
+			return nil, ErrContainerNotFound
+		}
+		return nil, err
+	}
+	return parseCLIStats(out)
+}
+
+// parseCLIStats parses a "12.34%|100MiB / 2GiB" line printed by the stats
+// command
+func parseCLIStats(out string) (*ContainerStats, error) {
+	cpu, memory, ok := strings.Cut(strings.TrimSpace(out), "|")
+	usage, limit, ok2 := strings.Cut(memory, "/")
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("unexpected stats output %q", out)
+	}
+
+	var stats ContainerStats
+	var err error
+	if stats.CPUPercent, err = strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(cpu), "%"), 64); err != nil {
+		return nil, fmt.Errorf("unexpected CPU usage %q", cpu)
+	}
+	if stats.MemoryUsage, err = parseHumanSize(usage); err != nil {
+		return nil, err
+	}
+	if stats.MemoryLimit, err = parseHumanSize(limit); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// parseHumanSize parses sizes such as 512MiB, 1.5GB or 300kB
+func parseHumanSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+		{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+		{"B", 1},
+	}
+	for _, unit := range units {
+		if number, ok := strings.CutSuffix(size, unit.suffix); ok {
+			value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+			if err != nil {
+				break
+			}
+			return int64(value * unit.multiplier), nil
+		}
+	}
+	return 0, fmt.Errorf("unexpected size %q", size)
+}
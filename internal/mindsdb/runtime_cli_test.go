@@ -0,0 +1,100 @@
+package mindsdb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsCLINotFound(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"Error: no such container: mindsdb-default", true},
+		{"Error: No such container: mindsdb-default", true},
+		{"Error: no such volume: mindsdb-default-data", true},
+		{"Error: mindsdb/mindsdb:v9: image not known", true},
+		{"FATA[0000] no such image: mindsdb/mindsdb:v9", true},
+		{"Error: no such object: \"mindsdb-default\"", true},
+		{"exec: \"podman\": executable file not found in $PATH", false},
+		{"Error: initializing source docker://mindsdb/mindsdb:v9: reading manifest v9: manifest unknown: not found", false},
+		{"Error: cannot remove container: container is running", false},
+	}
+	for _, tt := range tests {
+		if got := isCLINotFound(errors.New(tt.message)); got != tt.want {
+			t.Errorf("isCLINotFound(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestQualifyImage(t *testing.T) {
+	tests := []struct{ ref, want string }{
+		{"mindsdb/mindsdb:latest", "docker.io/mindsdb/mindsdb:latest"},
+		{"ubuntu", "docker.io/ubuntu"},
+		{"docker.io/mindsdb/mindsdb", "docker.io/mindsdb/mindsdb"},
+		{"ghcr.io/acme/mindsdb:v1", "ghcr.io/acme/mindsdb:v1"},
+		{"registry:5000/mindsdb", "registry:5000/mindsdb"},
+		{"localhost/mindsdb:dev", "localhost/mindsdb:dev"},
+		{"sha256:0123abcd", "sha256:0123abcd"},
+	}
+	for _, tt := range tests {
+		if got := qualifyImage(tt.ref); got != tt.want {
+			t.Errorf("qualifyImage(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestParseCLIStats(t *testing.T) {
+	tests := []struct {
+		out     string
+		want    ContainerStats
+		wantErr string
+	}{
+		{"12.50%|100.5MiB / 2GiB\n", ContainerStats{12.5, 105381888, 2 << 30}, ""},
+		{"0.00% | 0B / 0B", ContainerStats{0, 0, 0}, ""},
+		{"150.3%|1.2GB / 8GB", ContainerStats{150.3, 1200000000, 8000000000}, ""},
+		{"12.50%", ContainerStats{}, "unexpected stats output"},
+		{"--|1MiB / 2MiB", ContainerStats{}, "unexpected CPU usage"},
+		{"1%|lots / 2MiB", ContainerStats{}, "unexpected size"},
+	}
+	for _, tt := range tests {
+		got, err := parseCLIStats(tt.out)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseCLIStats(%q) error = %v, want %q", tt.out, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || *got != tt.want {
+			t.Errorf("parseCLIStats(%q) = %+v, %v; want %+v", tt.out, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseHumanSize(t *testing.T) {
+	tests := []struct {
+		size string
+		want int64
+	}{
+		{"512B", 512},
+		{"300kB", 300000},
+		{"300KB", 300000},
+		{"1.5KiB", 1536},
+		{"512MiB", 512 << 20},
+		{" 2GiB ", 2 << 30},
+		{"1TiB", 1 << 40},
+		{"1.5GB", 1500000000},
+		{"2 MB", 2000000},
+	}
+	for _, tt := range tests {
+		if got, err := parseHumanSize(tt.size); err != nil || got != tt.want {
+			t.Errorf("parseHumanSize(%q) = %d, %v; want %d", tt.size, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "512", "MiB", "1.5PiB"} {
+		if _, err := parseHumanSize(bad); err == nil {
+			t.Errorf("parseHumanSize(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package mindsdb
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// dockerRuntime implements Runtime on top of the Docker Engine API
+type dockerRuntime struct {
+	docker *DockerClient
+}
+
+func (r *dockerRuntime) Name() string {
+	return RuntimeDocker
+}
+
+func (r *dockerRuntime) Ping(ctx context.Context) error {
+	return r.docker.Ping(ctx)
+}
+
+func (r *dockerRuntime) Version(ctx context.Context) (string, error) {
+	return r.docker.ServerVersion(ctx)
+}
+
+func (r *dockerRuntime) PullImage(ctx context.Context, ref string) error {
+	return r.docker.ImagePull(ctx, ref)
+}
+
+func (r *dockerRuntime) LoadImage(ctx context.Context, archive io.Reader) ([]string, error) {
+	output, err := r.docker.ImageLoad(ctx, archive)
+	if err != nil {
+		return nil, err
+	}
+	return parseLoadedImages(output), nil
+}
+
+func (r *dockerRuntime) SaveImage(ctx context.Context, ref string, w io.Writer) error {
+	archive, err := r.docker.ImageSave(ctx, ref)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	_, err = io.Copy(w, archive)
+	return err
+}
+
+func (r *dockerRuntime) InspectImage(ctx context.Context, ref string) (*ImageInfo, error) {
+	image, err := r.docker.ImageInspect(ctx, ref)
+	if IsDockerNotFound(err) {
+		return nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ImageInfo{ID: image.ID, RepoDigests: image.RepoDigests}, nil
+}
+
+func (r *dockerRuntime) CreateContainer(ctx context.Context, spec ContainerSpec) (string, error) {
+	config := ContainerConfig{
+		Image:        spec.Image,
+		Env:          spec.Env,
+		Labels:       spec.Labels,
+		ExposedPorts: map[string]struct{}{},
+		HostConfig: HostConfig{
+			PortBindings: map[string][]PortBinding{},
+			NanoCpus:     int64(spec.CPUs * 1e9),
+			Memory:       spec.Memory,
+			NetworkMode:  spec.Network,
+			Binds:        append([]string(nil), spec.Mounts...),
+		},
+	}
+	for volume, path := range spec.Volumes {
+		config.HostConfig.Binds = append(config.HostConfig.Binds, volume+":"+path)
+	}
+	for containerPort, hostPort := range spec.Ports {
+		config.ExposedPorts[containerPort+"/tcp"] = struct{}{}
+		config.HostConfig.PortBindings[containerPort+"/tcp"] = []PortBinding{{HostPort: hostPort}}
+	}
+	return r.docker.ContainerCreate(ctx, spec.Name, config)
+}
+
+func (r *dockerRuntime) StartContainer(ctx context.Context, id string) error {
+	return r.docker.ContainerStart(ctx, id)
+}
+
+func (r *dockerRuntime) StopContainer(ctx context.Context, id string, timeout *time.Duration) error {
+	return r.docker.ContainerStop(ctx, id, timeout)
+}
+
+func (r *dockerRuntime) RemoveContainer(ctx context.Context, id string) error {
+	return r.docker.ContainerRemove(ctx, id)
+}
+
+func (r *dockerRuntime) InspectContainer(ctx context.Context, id string) (*ContainerInfo, error) {
+	container, err := r.docker.ContainerInspect(ctx, id)
+	if IsDockerNotFound(err) {
+		return nil, ErrContainerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return container.info(), nil
+}
+
+func (r *dockerRuntime) ListContainers(ctx context.Context, label string) ([]*ContainerInfo, error) {
+	ids, err := r.docker.ContainerList(ctx, map[string][]string{"label": {label}})
+	if err != nil {
+		return nil, err
+	}
+	return inspectAll(ctx, r, ids)
+}
+
+func (r *dockerRuntime) CopyFromContainer(ctx context.Context, id, path string) (io.ReadCloser, error) {
+	return r.docker.CopyFromContainer(ctx, id, path)
+}
+
+func (r *dockerRuntime) CopyToContainer(ctx context.Context, id, dir string, archive io.Reader) error {
+	return r.docker.CopyToContainer(ctx, id, dir, archive)
+}
+
+func (r *dockerRuntime) VolumeExists(ctx context.Context, name string) (bool, error) {
+	err := r.docker.VolumeInspect(ctx, name)
+	if IsDockerNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *dockerRuntime) RemoveVolume(ctx context.Context, name string) error {
+	err := r.docker.VolumeRemove(ctx, name)
+	if IsDockerNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *dockerRuntime) ContainerLogs(ctx context.Context, id string, opts LogsOptions) (io.ReadCloser, error) {
+	container, err := r.docker.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"stdout": {"1"}, "stderr": {"1"}}
+	if opts.Follow {
+		query.Set("follow", "1")
+	}
+	if opts.Timestamps {
+		query.Set("timestamps", "1")
+	}
+	if opts.Tail != "" {
+		query.Set("tail", opts.Tail)
+	}
+	if opts.Since != "" {
+		since, err := parseSince(opts.Since, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		query.Set("since", since)
+	}
+	return r.docker.ContainerLogs(ctx, id, query, container.Config.Tty)
+}
+
+func (r *dockerRuntime) ContainerStats(ctx context.Context, id string) (*ContainerStats, error) {
+	stats, err := r.docker.ContainerStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same computation as 'docker stats'
+	var cpuPercent float64
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	cpus := float64(stats.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		cpuPercent = cpuDelta / systemDelta * cpus * 100
+	}
+
+	// The page cache is reclaimable, so it does not count as usage
+	memory := stats.MemoryStats.Usage
+	if cache, ok := stats.MemoryStats.Stats["inactive_file"]; ok && cache < memory {
+		memory -= cache // cgroup v2
+	} else if cache, ok := stats.MemoryStats.Stats["total_inactive_file"]; ok && cache < memory {
+		memory -= cache // cgroup v1
+	}
+
+	return &ContainerStats{CPUPercent: cpuPercent, MemoryUsage: memory, MemoryLimit: stats.MemoryStats.Limit}, nil
+}
+
+// info converts an inspect response into a ContainerInfo. Podman and
+// nerdctl produce the same document shape, so the CLI runtime uses it too.
+func (c *ContainerJSON) info() *ContainerInfo {
+	info := &ContainerInfo{
+		ID:        c.ID,
+		Name:      strings.TrimPrefix(c.Name, "/"),
+		Image:     c.Config.Image,
+		ImageID:   c.Image,
+		Status:    c.State.Status,
+		Running:   c.State.Running,
+		StartedAt: c.State.StartedAt,
+		Labels:    c.Config.Labels,
+		Env:       c.Config.Env,
+		Ports:     map[string]string{},
+		Volumes:   map[string]string{},
+	}
+	for _, mount := range c.Mounts {
+		if mount.Type == "volume" {
+			info.Volumes[mount.Name] = mount.Destination
+		}
+	}
+	if c.State.Health != nil {
+		info.Health = c.State.Health.Status
+	}
+	// The requested bindings are kept while the container is stopped; the
+	// published ports take precedence since they show the actual port
+	for _, ports := range []map[string][]PortBinding{c.HostConfig.PortBindings, c.NetworkSettings.Ports} {
+		for port, bindings := range ports {
+			if len(bindings) > 0 && bindings[0].HostPort != "" {
+				containerPort, _, _ := strings.Cut(port, "/")
+				info.Ports[containerPort] = bindings[0].HostPort
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,248 @@
+package mindsdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRuntime is an in-memory Runtime. Like Docker it stops reporting a
+// container's published ports once the container is stopped. Every call
+// that changes state is recorded in calls.
+type fakeRuntime struct {
+	containers map[string]*ContainerInfo // By name
+	images     map[string]*ImageInfo     // By reference
+	volumes    map[string]bool
+	archive    []byte   // Returned by CopyFromContainer
+	loaded     []string // Returned by LoadImage
+	calls      []string
+	nextID     int
+}
+
+var _ Runtime = (*fakeRuntime)(nil)
+
+func newFakeRuntime(containers ...*ContainerInfo) *fakeRuntime {
+	rt := &fakeRuntime{
+		containers: map[string]*ContainerInfo{},
+		images:     map[string]*ImageInfo{},
+		volumes:    map[string]bool{},
+	}
+	for _, c := range containers {
+		if c.ID == "" {
+			c.ID = "id-" + c.Name
+		}
+		rt.containers[c.Name] = c
+		for volume := range c.Volumes {
+			rt.volumes[volume] = true
+		}
+	}
+	return rt
+}
+
+func (r *fakeRuntime) record(format string, args ...interface{}) {
+	r.calls = append(r.calls, fmt.Sprintf(format, args...))
+}
+
+func (r *fakeRuntime) lookup(id string) (*ContainerInfo, error) {
+	for _, c := range r.containers {
+		if c.ID == id || c.Name == id {
+			return c, nil
+		}
+	}
+	return nil, ErrContainerNotFound
+}
+
+func (r *fakeRuntime) Name() string                                { return "fake" }
+func (r *fakeRuntime) Ping(ctx context.Context) error              { return nil }
+func (r *fakeRuntime) Version(ctx context.Context) (string, error) { return "1.0", nil }
+func (r *fakeRuntime) SaveImage(context.Context, string, io.Writer) error {
+	return errors.New("not supported")
+}
+
+func (r *fakeRuntime) PullImage(ctx context.Context, ref string) error {
+	r.record("pull %s", ref)
+	r.images[ref] = &ImageInfo{ID: "sha256:pulled"}
+	return nil
+}
+
+func (r *fakeRuntime) LoadImage(ctx context.Context, archive io.Reader) ([]string, error) {
+	r.record("load")
+	return r.loaded, nil
+}
+
+func (r *fakeRuntime) InspectImage(ctx context.Context, ref string) (*ImageInfo, error) {
+	if info, ok := r.images[ref]; ok {
+		return info, nil
+	}
+	return nil, ErrImageNotFound
+}
+
+func (r *fakeRuntime) CreateContainer(ctx context.Context, spec ContainerSpec) (string, error) {
+	if _, exists := r.containers[spec.Name]; exists {
+		return "", fmt.Errorf("container name %s is already in use", spec.Name)
+	}
+	r.nextID++
+	c := &ContainerInfo{
+		ID:      fmt.Sprintf("new-%d", r.nextID),
+		Name:    spec.Name,
+		Image:   spec.Image,
+		Status:  "created",
+		Labels:  spec.Labels,
+		Env:     spec.Env,
+		Volumes: spec.Volumes,
+	}
+	r.containers[spec.Name] = c
+	for volume := range spec.Volumes {
+		r.volumes[volume] = true
+	}
+	r.record("create %s", spec.Name)
+	return c.ID, nil
+}
+
+func (r *fakeRuntime) StartContainer(ctx context.Context, id string) error {
+	c, err := r.lookup(id)
+	if err != nil {
+		return err
+	}
+	c.Running, c.Status = true, "running"
+	c.StartedAt = time.Now().Format(time.RFC3339)
+	ports := containerPorts(c)
+	c.Ports = map[string]string{MindsDBPort: ports.HTTP, MySQLPort: ports.MySQL}
+	r.record("start %s", c.Name)
+	return nil
+}
+
+func (r *fakeRuntime) StopContainer(ctx context.Context, id string, timeout *time.Duration) error {
+	c, err := r.lookup(id)
+	if err != nil {
+		return err
+	}
+	c.Running, c.Status, c.Ports = false, "exited", nil
+	r.record("stop %s", c.Name)
+	return nil
+}
+
+func (r *fakeRuntime) RemoveContainer(ctx context.Context, id string) error {
+	c, err := r.lookup(id)
+	if err != nil {
+		return err
+	}
+	delete(r.containers, c.Name)
+	r.record("remove %s", c.Name)
+	return nil
+}
+
+func (r *fakeRuntime) InspectContainer(ctx context.Context, id string) (*ContainerInfo, error) {
+	return r.lookup(id)
+}
+
+func (r *fakeRuntime) ListContainers(ctx context.Context, label string) ([]*ContainerInfo, error) {
+	var containers []*ContainerInfo
+	for _, c := range r.containers {
+		if _, ok := c.Labels[label]; ok {
+			containers = append(containers, c)
+		}
+	}
+	return containers, nil
+}
+
+func (r *fakeRuntime) CopyFromContainer(ctx context.Context, id, path string) (io.ReadCloser, error) {
+	c, err := r.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	r.record("copy %s from %s", path, c.Name)
+	return io.NopCloser(bytes.NewReader(r.archive)), nil
+}
+
+func (r *fakeRuntime) CopyToContainer(ctx context.Context, id, dir string, archive io.Reader) error {
+	c, err := r.lookup(id)
+	if err != nil {
+		return err
+	}
+	r.record("copy to %s in %s", dir, c.Name)
+	_, err = io.Copy(io.Discard, archive)
+	return err
+}
+
+func (r *fakeRuntime) VolumeExists(ctx context.Context, name string) (bool, error) {
+	return r.volumes[name], nil
+}
+
+func (r *fakeRuntime) RemoveVolume(ctx context.Context, name string) error {
+	delete(r.volumes, name)
+	r.record("remove volume %s", name)
+	return nil
+}
+
+func (r *fakeRuntime) ContainerLogs(ctx context.Context, id string, opts LogsOptions) (io.ReadCloser, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *fakeRuntime) ContainerStats(ctx context.Context, id string) (*ContainerStats, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestParseLoadedImages(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name:   "docker",
+			output: "Loaded image: mindsdb/mindsdb:v25.1.2\n",
+			want:   []string{"mindsdb/mindsdb:v25.1.2"},
+		},
+		{
+			name:   "docker untagged archive",
+			output: "Loaded image ID: sha256:0123abcd\n",
+			want:   []string{"sha256:0123abcd"},
+		},
+		{
+			name:   "docker several images",
+			output: "Loaded image: mindsdb/mindsdb:latest\nLoaded image: postgres:16\n",
+			want:   []string{"mindsdb/mindsdb:latest", "postgres:16"},
+		},
+		{
+			name: "podman",
+			output: "Getting image source signatures\n" +
+				"Copying blob 5f70bf18a086 done\n" +
+				"Writing manifest to image destination\n" +
+				"Loaded image: docker.io/mindsdb/mindsdb:latest\n",
+			want: []string{"docker.io/mindsdb/mindsdb:latest"},
+		},
+		{
+			name:   "older podman",
+			output: "Loaded image(s): docker.io/mindsdb/mindsdb:latest,docker.io/library/postgres:16\n",
+			want:   []string{"docker.io/mindsdb/mindsdb:latest", "docker.io/library/postgres:16"},
+		},
+		{
+			name:   "nerdctl",
+			output: "Loaded image: docker.io/mindsdb/mindsdb:v25.1.2\n",
+			want:   []string{"docker.io/mindsdb/mindsdb:v25.1.2"},
+		},
+		{
+			name:   "older nerdctl",
+			output: "unpacking docker.io/mindsdb/mindsdb:v25.1.2 (sha256:9f1c0a0b2e4d)...done\n",
+			want:   []string{"docker.io/mindsdb/mindsdb:v25.1.2"},
+		},
+		{
+			name:   "nothing loaded",
+			output: "open /tmp/image.tar: no such file or directory\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLoadedImages(tt.output)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseLoadedImages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package mindsdb
+
+import "strings"
+
+// QuoteIdentifier quotes a MindsDB identifier (project, model, column,
+// ...) with backticks, doubling any backtick inside it
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// QuoteString quotes a SQL string literal, escaping quotes and backslashes
+func QuoteString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
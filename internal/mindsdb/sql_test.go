@@ -0,0 +1,34 @@
+package mindsdb
+
+import "testing"
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"home_rentals", "`home_rentals`"},
+		{"my model", "`my model`"},
+		{"a`b", "`a``b`"},
+		{"", "``"},
+	}
+	for _, tt := range tests {
+		if got := QuoteIdentifier(tt.name); got != tt.want {
+			t.Errorf("QuoteIdentifier(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	tests := []struct{ value, want string }{
+		{"lightwood", "'lightwood'"},
+		{"it's", "'it''s'"},
+		{`C:\data`, `'C:\\data'`},
+		// A trailing backslash must not escape the closing quote
+		{`x\`, `'x\\'`},
+		{`\' OR 1=1 --`, `'\\'' OR 1=1 --'`},
+		{"", "''"},
+	}
+	for _, tt := range tests {
+		if got := QuoteString(tt.value); got != tt.want {
+			t.Errorf("QuoteString(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
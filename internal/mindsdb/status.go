@@ -0,0 +1,184 @@
+package mindsdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// StateNotCreated is the InstanceStatus state of an instance without a
+// container; otherwise the runtime's container state is reported
+const StateNotCreated = "not created"
+
+// APIStatus is the result of probing one of MindsDB's APIs
+type APIStatus struct {
+	Address    string `json:"address" yaml:"address"`
+	Reachable  bool   `json:"reachable" yaml:"reachable"`
+	StatusCode int    `json:"status_code,omitempty" yaml:"status_code,omitempty"` // HTTP only
+	LatencyMS  int64  `json:"latency_ms" yaml:"latency_ms"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// InstanceStatus describes an embedded instance, its container and the
+// health of its APIs
+type InstanceStatus struct {
+	Instance       string          `json:"instance" yaml:"instance"`
+	Runtime        string          `json:"runtime" yaml:"runtime"`
+	RuntimeVersion string          `json:"runtime_version,omitempty" yaml:"runtime_version,omitempty"`
+	Container      string          `json:"container" yaml:"container"`
+	State          string          `json:"state" yaml:"state"`
+	Running        bool            `json:"running" yaml:"running"`
+	Health         string          `json:"health,omitempty" yaml:"health,omitempty"` // Container health check, "none" without one
+	StartedAt      string          `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	UptimeSeconds  int64           `json:"uptime_seconds,omitempty" yaml:"uptime_seconds,omitempty"`
+	Image          string          `json:"image,omitempty" yaml:"image,omitempty"`
+	Digest         string          `json:"digest,omitempty" yaml:"digest,omitempty"`
+	PreviousImage  string          `json:"previous_image,omitempty" yaml:"previous_image,omitempty"`
+	Ports          *Ports          `json:"ports,omitempty" yaml:"ports,omitempty"`
+	AuthUser       string          `json:"auth_user,omitempty" yaml:"auth_user,omitempty"` // Empty when authentication is disabled
+	Stats          *ContainerStats `json:"stats,omitempty" yaml:"stats,omitempty"`
+	HTTP           *APIStatus      `json:"http,omitempty" yaml:"http,omitempty"`
+	MySQL          *APIStatus      `json:"mysql,omitempty" yaml:"mysql,omitempty"`
+	ServerVersion  string          `json:"server_version,omitempty" yaml:"server_version,omitempty"` // From the SQL probe
+	Errors         []string        `json:"errors,omitempty" yaml:"errors,omitempty"`                 // Checks that could not be run
+}
+
+// Healthy reports whether the container runs and both APIs answer
+func (s *InstanceStatus) Healthy() bool {
+	return s.Running && s.HTTP != nil && s.HTTP.Reachable && s.MySQL != nil && s.MySQL.Reachable
+}
+
+// InstanceStatus inspects the instance's container and, when it is running,
+// collects its resource usage and probes the HTTP and MySQL APIs. Failing
+// checks are recorded in the result; only failing to inspect the container
+// is an error. The SQL probe uses the credentials stored by 'start'.
+func (c *MindsDBClient) InstanceStatus(ctx context.Context) (*InstanceStatus, error) {
+	rt, err := c.containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &InstanceStatus{
+		Instance:  instanceName(c.Instance),
+		Runtime:   rt.Name(),
+		Container: c.containerName(),
+		State:     StateNotCreated,
+	}
+	if version, err := rt.Version(ctx); err == nil {
+		status.RuntimeVersion = version
+	}
+
+	container, err := c.inspectExisting()
+	if err != nil {
+		return nil, err
+	}
+	if container == nil {
+		return status, nil
+	}
+
+	c.Ports = containerPorts(container)
+	ports := c.Ports
+	// Ports are left out when a stopped container does not say which it uses
+	if _, known := recordedPorts(container); known || container.Running {
+		status.Ports = &ports
+	}
+	status.Container = container.Name
+	status.State = container.Status
+	status.Running = container.Running
+	status.StartedAt = container.StartedAt
+	status.Image, status.Digest = ContainerImage(container)
+	status.PreviousImage = container.Labels[PreviousImageLabel]
+	status.AuthUser = AuthUser(container)
+	if !container.Running {
+		return status, nil
+	}
+
+	status.Health = container.Health
+	if status.Health == "" {
+		status.Health = "none"
+	}
+	if started, err := time.Parse(time.RFC3339Nano, container.StartedAt); err == nil {
+		status.UptimeSeconds = int64(time.Since(started).Seconds())
+	}
+
+	if stats, err := rt.ContainerStats(ctx, container.ID); err == nil {
+		status.Stats = stats
+	} else {
+		status.Errors = append(status.Errors, fmt.Sprintf("stats: %v", err))
+	}
+
+	status.HTTP = probeHTTP(ctx, "localhost:"+ports.HTTP)
+	status.MySQL, status.ServerVersion = c.probeSQL(ctx, container, "localhost:"+ports.MySQL)
+	return status, nil
+}
+
+// probeHTTP checks that the HTTP API answers. Any response counts, since
+// instances with authentication reject anonymous requests.
+func probeHTTP(ctx context.Context, addr string) *APIStatus {
+	status := &APIStatus{Address: addr}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/api/status", nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	resp, err := http.DefaultClient.Do(req)
+	status.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	resp.Body.Close()
+
+	status.StatusCode = resp.StatusCode
+	status.Reachable = resp.StatusCode < http.StatusInternalServerError
+	if !status.Reachable {
+		status.Error = resp.Status
+	}
+	return status
+}
+
+// probeSQL connects over the MySQL protocol with the instance's
+// credentials and asks for the server version
+func (c *MindsDBClient) probeSQL(ctx context.Context, container *ContainerInfo, addr string) (*APIStatus, string) {
+	status := &APIStatus{Address: addr}
+	cred, err := c.credentialFor(container, "", "")
+	if err != nil {
+		status.Error = err.Error()
+		return status, ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	start := time.Now()
+	conn, err := newMySQLExecutor(ctx, mysqlConfig(cred.user, cred.pass, addr, "mindsdb", nil))
+	if err != nil {
+		status.LatencyMS = time.Since(start).Milliseconds()
+		status.Error = err.Error()
+		return status, ""
+	}
+	defer conn.Close()
+
+	var version string
+	rows, err := conn.Query(ctx, "SELECT VERSION()")
+	if err == nil {
+		if rows.Next() {
+			err = rows.Scan(&version)
+		}
+		if err == nil {
+			err = rows.Err()
+		}
+		rows.Close()
+	}
+	status.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status, ""
+	}
+	status.Reachable = true
+	return status, version
+}
@@ -0,0 +1,273 @@
+package mindsdb
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPKI holds a CA and the PEM files of certificates it signed
+type testPKI struct {
+	dir    string
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caFile string
+	serial int64
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	p := &testPKI{dir: t.TempDir()}
+	p.ca, p.caKey, p.caFile = p.issue(t, "ca", &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	return p
+}
+
+// issue creates a certificate from template, signed by the CA (or by itself
+// for the CA), and writes it to <name>.pem and <name>-key.pem
+func (p *testPKI) issue(t *testing.T, name string, template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.serial++
+	template.SerialNumber = big.NewInt(p.serial)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parent, parentKey := template, key
+	if p.ca != nil {
+		parent, parentKey = p.ca, p.caKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(p.dir, name+".pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, filepath.Join(p.dir, name+"-key.pem"), "EC PRIVATE KEY", keyDER)
+	return cert, key, certFile
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// keyPair loads a certificate issued as name
+func (p *testPKI) keyPair(t *testing.T, name string) tls.Certificate {
+	t.Helper()
+	cert, err := tls.LoadX509KeyPair(filepath.Join(p.dir, name+".pem"), filepath.Join(p.dir, name+"-key.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// handshake runs a TLS handshake between a local listener using server and
+// a client using client, returning the first error seen by either side
+func handshake(t *testing.T, server, client *tls.Config) error {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if err := conn.(*tls.Conn).Handshake(); err != nil {
+			serverErr <- err
+			return
+		}
+		_, err = conn.Write([]byte("x"))
+		serverErr <- err
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), client)
+	if err == nil {
+		// With TLS 1.3 the server checks the client certificate after the
+		// client's handshake has finished; a rejection arrives on read
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	if sErr := <-serverErr; err == nil {
+		err = sErr
+	}
+	return err
+}
+
+func TestTLSModes(t *testing.T) {
+	pki := newTestPKI(t)
+	// The server certificate is for mindsdb.internal, not for the address
+	// the client dials
+	pki.issue(t, "server", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "mindsdb.internal"},
+		DNSNames:    []string{"mindsdb.internal"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	server := &tls.Config{Certificates: []tls.Certificate{pki.keyPair(t, "server")}}
+
+	// A second CA that did not sign the server certificate
+	otherCA := newTestPKI(t)
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr string
+	}{
+		{"require skips verification", TLSOptions{Mode: SSLModeRequire}, ""},
+		{"verify-ca ignores the host name", TLSOptions{Mode: SSLModeVerifyCA, CAFile: pki.caFile}, ""},
+		{"verify-ca rejects an unknown CA", TLSOptions{Mode: SSLModeVerifyCA, CAFile: otherCA.caFile}, "unknown authority"},
+		{"verify-full rejects a host name mismatch", TLSOptions{Mode: SSLModeVerifyFull, CAFile: pki.caFile}, "not localhost"},
+		{"verify-full with a server name", TLSOptions{Mode: SSLModeVerifyFull, CAFile: pki.caFile, ServerName: "mindsdb.internal"}, ""},
+		{"verify-full rejects an unknown CA", TLSOptions{Mode: SSLModeVerifyFull, CAFile: otherCA.caFile, ServerName: "mindsdb.internal"}, "unknown authority"},
+		{"certificate options imply verify-full", TLSOptions{CAFile: pki.caFile}, "not localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.opts.Config("localhost:47335")
+			if err != nil {
+				t.Fatalf("Config() error = %v", err)
+			}
+			err = handshake(t, server, client)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("handshake error = %v, want success", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("handshake error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSDisabled(t *testing.T) {
+	for _, opts := range []TLSOptions{{}, {Mode: SSLModeDisable}, {Mode: SSLModeDisable, CAFile: "ca.pem"}} {
+		cfg, err := opts.Config("localhost:47335")
+		if err != nil || cfg != nil {
+			t.Errorf("%+v: Config() = %v, %v; want no TLS", opts, cfg, err)
+		}
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	pki.issue(t, "server", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	pki.issue(t, "client", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "admin"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(pki.ca)
+	server := &tls.Config{
+		Certificates: []tls.Certificate{pki.keyPair(t, "server")},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+
+	withCert := TLSOptions{
+		Mode:     SSLModeVerifyFull,
+		CAFile:   pki.caFile,
+		CertFile: filepath.Join(pki.dir, "client.pem"),
+		KeyFile:  filepath.Join(pki.dir, "client-key.pem"),
+	}
+	client, err := withCert.Config("localhost:47335")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(t, server, client); err != nil {
+		t.Errorf("handshake with a client certificate error = %v", err)
+	}
+
+	client, err = TLSOptions{Mode: SSLModeVerifyFull, CAFile: pki.caFile}.Config("localhost:47335")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(t, server, client); err == nil {
+		t.Error("handshake without a client certificate succeeded")
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	pki := newTestPKI(t)
+	notPEM := filepath.Join(pki.dir, "empty.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr string
+	}{
+		{"unknown mode", TLSOptions{Mode: "prefer"}, "unsupported sslmode"},
+		{"certificate without key", TLSOptions{CertFile: pki.caFile}, "both a client certificate and key"},
+		{"missing CA bundle", TLSOptions{CAFile: filepath.Join(pki.dir, "missing.pem")}, "failed to read CA bundle"},
+		{"CA bundle without certificates", TLSOptions{CAFile: notPEM}, "no certificates found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.opts.Config("localhost")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Config() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSServerName(t *testing.T) {
+	tests := []struct {
+		host, serverName, want string
+	}{
+		{"db.example.com:5432", "", "db.example.com"},
+		{"db.example.com", "", "db.example.com"},
+		{"10.0.0.5:5432", "mindsdb.internal", "mindsdb.internal"},
+	}
+	for _, tt := range tests {
+		cfg, err := TLSOptions{Mode: SSLModeVerifyFull, ServerName: tt.serverName}.Config(tt.host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.ServerName != tt.want {
+			t.Errorf("Config(%q).ServerName = %q, want %q", tt.host, cfg.ServerName, tt.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"time"
+)
+
+// DefaultWaitTimeout is how long to wait for MindsDB to accept SQL
+// connections when no timeout is configured. A freshly pulled image can
+// take several minutes to initialize on a slow machine.
+const DefaultWaitTimeout = 3 * time.Minute
+
+// Readiness phases, checked in this order
+const (
+	PhaseContainerUp = "container up"
+	PhasePortOpen    = "port open"
+	PhaseSQLPing     = "SQL ping"
+	PhaseSQLProbe    = "SQL probe"
+)
+
+// WaitOptions controls WaitForExternal and WaitForEmbedded
+type WaitOptions struct {
+	Timeout time.Duration                 // DefaultWaitTimeout when zero
+	Probe   string                        // Optional statement that must succeed, e.g. SELECT 1 FROM models
+	OnRetry func(phase string, err error) // Called before each retry, may be nil
+}
+
+// ReadinessError reports the phase that was still failing when waiting for
+// MindsDB gave up
+type ReadinessError struct {
+	Phase   string
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *ReadinessError) Error() string {
+	return fmt.Sprintf("MindsDB did not become ready after %s: %s check failed: %v",
+		e.Elapsed.Round(time.Second), e.Phase, e.Err)
+}
+
+func (e *ReadinessError) Unwrap() error {
+	return e.Err
+}
+
+// backoff produces exponentially growing delays with jitter so that many
+// clients waiting on the same instance do not poll in lockstep
+type backoff struct {
+	initial time.Duration
+	max     time.Duration
+	attempt int
+}
+
+func newBackoff() *backoff {
+	return &backoff{initial: 500 * time.Millisecond, max: 10 * time.Second}
+}
+
+// next returns the delay before the next attempt: half of the exponential
+// step plus a random share of the other half
+func (b *backoff) next() time.Duration {
+	delay := b.initial << b.attempt
+	if delay <= 0 || delay > b.max {
+		delay = b.max
+	} else {
+		b.attempt++
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// readinessCheck runs one readiness attempt and returns the phase that
+// failed along with its error, or an empty phase when ready
+type readinessCheck func(ctx context.Context) (string, error)
+
+// permanentError marks a readiness failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// waitUntilReady repeats check with exponential backoff until it succeeds,
+// returns a permanentError, or timeout expires. onRetry is called before
+// every retry.
+func waitUntilReady(timeout time.Duration, check readinessCheck, onRetry func(string, error)) error {
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	delays := newBackoff()
+	for {
+		phase, err := check(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return &ReadinessError{Phase: phase, Elapsed: time.Since(start), Err: permanent.err}
+		}
+
+		select {
+		case <-ctx.Done():
+			return &ReadinessError{Phase: phase, Elapsed: time.Since(start), Err: err}
+		case <-time.After(delays.next()):
+			if onRetry != nil {
+				onRetry(phase, err)
+			}
+		}
+	}
+}
+
+// checkPortOpen verifies that addr accepts TCP connections
+func checkPortOpen(ctx context.Context, addr string) error {
+	dialer := net.Dialer{Timeout: 2 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// runProbe runs the optional readiness statement and reads its result
+func runProbe(ctx context.Context, conn Executor, probe string) error {
+	if probe == "" {
+		return nil
+	}
+
+	rows, err := conn.Query(ctx, probe)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		// Only success matters, the result itself is discarded
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	return rows.Close()
+}
+
+// WaitForExternal blocks until an external MindsDB instance accepts SQL
+// connections: first the host's port must accept TCP connections, then a
+// connection with opts must succeed, and finally the probe, if any.
+func WaitForExternal(opts ConnectOptions, wait WaitOptions) error {
+	addr := dialAddress(opts)
+
+	return waitUntilReady(wait.Timeout, func(ctx context.Context) (string, error) {
+		if err := checkPortOpen(ctx, addr); err != nil {
+			return PhasePortOpen, err
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		client, err := ConnectContext(pingCtx, opts)
+		if err != nil {
+			return PhaseSQLPing, err
+		}
+		defer client.Close()
+
+		if err := runProbe(ctx, client.Conn, wait.Probe); err != nil {
+			return PhaseSQLProbe, err
+		}
+		return "", nil
+	}, wait.OnRetry)
+}
+
+// dialAddress returns the host:port the drivers connect to for opts,
+// applying each driver's default port when none is given
+func dialAddress(opts ConnectOptions) string {
+	host := opts.Host
+	port := ""
+
+	switch opts.Protocol {
+	case ProtocolMySQL:
+		port = "3306"
+	case ProtocolHTTP:
+		port = "80"
+		if opts.TLS.Enabled() {
+			port = "443"
+		}
+		if u, err := url.Parse(opts.Host); err == nil && u.Host != "" {
+			host = u.Host
+			if u.Scheme == "https" {
+				port = "443"
+			}
+		}
+	default:
+		port = "5432"
+	}
+
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
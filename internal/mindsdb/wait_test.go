@@ -0,0 +1,83 @@
+package mindsdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	b := newBackoff()
+	steps := []time.Duration{
+		500 * time.Millisecond, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		10 * time.Second, 10 * time.Second,
+	}
+	for i, step := range steps {
+		// Half of the step plus jitter of up to the other half
+		if delay := b.next(); delay < step/2 || delay > step {
+			t.Errorf("attempt %d: delay %s, want between %s and %s", i, delay, step/2, step)
+		}
+	}
+}
+
+func TestBackoffDoesNotOverflow(t *testing.T) {
+	b := newBackoff()
+	for i := 0; i < 100; i++ {
+		if delay := b.next(); delay <= 0 || delay > b.max {
+			t.Fatalf("attempt %d: delay %s", i, delay)
+		}
+	}
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	attempts := 0
+	var retried []string
+	err := waitUntilReady(time.Minute, func(ctx context.Context) (string, error) {
+		attempts++
+		if attempts < 2 {
+			return PhasePortOpen, errors.New("connection refused")
+		}
+		return "", nil
+	}, func(phase string, err error) {
+		retried = append(retried, phase+": "+err.Error())
+	})
+	if err != nil {
+		t.Fatalf("waitUntilReady() error = %v", err)
+	}
+	if attempts != 2 || len(retried) != 1 || retried[0] != "port open: connection refused" {
+		t.Errorf("attempts = %d, retries = %v", attempts, retried)
+	}
+}
+
+func TestWaitUntilReadyPermanentError(t *testing.T) {
+	authErr := errors.New("password authentication failed")
+	attempts := 0
+	err := waitUntilReady(time.Minute, func(ctx context.Context) (string, error) {
+		attempts++
+		return PhaseSQLPing, &permanentError{authErr}
+	}, nil)
+
+	var readiness *ReadinessError
+	if !errors.As(err, &readiness) || readiness.Phase != PhaseSQLPing || !errors.Is(err, authErr) {
+		t.Fatalf("waitUntilReady() error = %v, want a SQL ping ReadinessError wrapping the cause", err)
+	}
+	if attempts != 1 {
+		t.Errorf("a permanent error was retried %d times", attempts-1)
+	}
+}
+
+func TestWaitUntilReadyTimeout(t *testing.T) {
+	err := waitUntilReady(50*time.Millisecond, func(ctx context.Context) (string, error) {
+		return PhaseSQLProbe, errors.New("table models not found")
+	}, nil)
+
+	var readiness *ReadinessError
+	if !errors.As(err, &readiness) || readiness.Phase != PhaseSQLProbe {
+		t.Fatalf("waitUntilReady() error = %v, want a SQL probe ReadinessError", err)
+	}
+	if want := "SQL probe check failed: table models not found"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Error() = %q, want it to contain %q", err.Error(), want)
+	}
+}